@@ -5,6 +5,7 @@ go 1.21.7
 require (
 	github.com/acarl005/stripansi v0.0.0-20180116102854-5a71ef0e047d
 	github.com/cucumber/godog v0.12.6
+	github.com/make-software/casper-go-sdk v1.5.2-0.20240228154659-7f7e95235434
 	gopkg.in/yaml.v2 v2.4.0
 )
 
@@ -12,7 +13,6 @@ require (
 	github.com/antchfx/xpath v1.2.3 // indirect
 	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1 // indirect
 	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
-	github.com/make-software/casper-go-sdk v1.5.2-0.20240228154659-7f7e95235434 // indirect
 )
 
 require (
@@ -33,6 +33,6 @@ require (
 	github.com/shopspring/decimal v1.3.1 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
 	github.com/stretchr/testify v1.8.4
-	golang.org/x/crypto v0.18.0 // indirect
+	golang.org/x/crypto v0.18.0
 	golang.org/x/sys v0.16.0 // indirect
 )
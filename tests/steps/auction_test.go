@@ -0,0 +1,259 @@
+package steps
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/make-software/casper-go-sdk/casper"
+	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/types"
+	"github.com/make-software/casper-go-sdk/types/clvalue"
+	"github.com/make-software/casper-go-sdk/types/keypair"
+
+	"github.com/casper-sdks/terminus-go-tests/tests/utils"
+)
+
+// The test features implementation for the auction.feature
+func TestFeaturesAuction(t *testing.T) {
+	utils.TestFeatures(t, "auction.feature", InitializeAuction)
+}
+
+func InitializeAuction(ctx *godog.ScenarioContext) {
+	var sdk casper.RPCClient
+	var auctionDeployResult rpc.PutDeployResult
+	var auctionDeploy rpc.InfoGetDeployResult
+	var stakedBefore *big.Int
+	var eraOfDeploy uint32
+
+	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+		utils.ReadConfig()
+		sdk = utils.GetRPCClient()
+		stakedBefore = big.NewInt(0)
+		return ctx, nil
+	})
+
+	ctx.Step(`^that user-(\d+) delegates (\d+) motes to validator node-(\d+) via the auction contract$`,
+		func(userId int, amount int64, nodeId int) error {
+			delegatorKey, validatorKey, err := getAuctionKeys(userId, nodeId)
+
+			if err == nil {
+				stakedBefore, err = getDelegatedAmount(sdk, validatorKey.PublicKey(), delegatorKey.PublicKey())
+			}
+
+			if err == nil {
+				args := &types.Args{}
+				args.AddArgument("delegator", clvalue.NewCLPublicKey(delegatorKey.PublicKey())).
+					AddArgument("validator", clvalue.NewCLPublicKey(validatorKey.PublicKey())).
+					AddArgument("amount", *clvalue.NewCLUInt512(big.NewInt(amount)))
+
+				auctionDeployResult, err = utils.PutAuctionDeploy("delegate", args, delegatorKey)
+			}
+
+			return err
+		})
+
+	ctx.Step(`^that user-(\d+) undelegates (\d+) motes from validator node-(\d+) via the auction contract$`,
+		func(userId int, amount int64, nodeId int) error {
+			delegatorKey, validatorKey, err := getAuctionKeys(userId, nodeId)
+
+			if err == nil {
+				stakedBefore, err = getDelegatedAmount(sdk, validatorKey.PublicKey(), delegatorKey.PublicKey())
+			}
+
+			if err == nil {
+				args := &types.Args{}
+				args.AddArgument("delegator", clvalue.NewCLPublicKey(delegatorKey.PublicKey())).
+					AddArgument("validator", clvalue.NewCLPublicKey(validatorKey.PublicKey())).
+					AddArgument("amount", *clvalue.NewCLUInt512(big.NewInt(amount)))
+
+				auctionDeployResult, err = utils.PutAuctionDeploy("undelegate", args, delegatorKey)
+			}
+
+			return err
+		})
+
+	ctx.Step(`^that user-(\d+) redelegates (\d+) motes from validator node-(\d+) to validator node-(\d+) via the auction contract$`,
+		func(userId int, amount int64, nodeId int, newNodeId int) error {
+			delegatorKey, validatorKey, err := getAuctionKeys(userId, nodeId)
+
+			var newValidatorKey keypair.PrivateKey
+			if err == nil {
+				newValidatorKey, err = casper.NewED25519PrivateKeyFromPEMFile(utils.GetNodeKeyAssetPath(1, newNodeId, "secret_key.pem"))
+			}
+
+			if err == nil {
+				stakedBefore, err = getDelegatedAmount(sdk, validatorKey.PublicKey(), delegatorKey.PublicKey())
+			}
+
+			if err == nil {
+				args := &types.Args{}
+				args.AddArgument("delegator", clvalue.NewCLPublicKey(delegatorKey.PublicKey())).
+					AddArgument("validator", clvalue.NewCLPublicKey(validatorKey.PublicKey())).
+					AddArgument("amount", *clvalue.NewCLUInt512(big.NewInt(amount))).
+					AddArgument("new_validator", clvalue.NewCLPublicKey(newValidatorKey.PublicKey()))
+
+				auctionDeployResult, err = utils.PutAuctionDeploy("redelegate", args, delegatorKey)
+			}
+
+			return err
+		})
+
+	ctx.Step(`^the auction deploy is successfully executed within (\d+) seconds$`, func(timeout int) error {
+		var err error
+		var block rpc.ChainGetBlockResult
+
+		auctionDeploy, err = utils.WaitForDeploy(auctionDeployResult.DeployHash.String(), timeout)
+
+		if err == nil && auctionDeploy.ExecutionResults[0].Result.Success == nil {
+			err = fmt.Errorf("auction deploy %s failed: %s",
+				auctionDeployResult.DeployHash.String(),
+				auctionDeploy.ExecutionResults[0].Result.Failure.ErrorMessage)
+		}
+
+		if err == nil {
+			block, err = sdk.GetBlockByHash(context.Background(), auctionDeploy.ExecutionResults[0].BlockHash.String())
+		}
+
+		if err == nil {
+			eraOfDeploy = block.Block.Header.EraID
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the auction info bids show user-(\d+) delegating (\d+) more motes to validator node-(\d+)$`,
+		func(userId int, amount int64, nodeId int) error {
+			delegatorKey, validatorKey, err := getAuctionKeys(userId, nodeId)
+
+			var stakedAfter *big.Int
+			if err == nil {
+				stakedAfter, err = getDelegatedAmount(sdk, validatorKey.PublicKey(), delegatorKey.PublicKey())
+			}
+
+			if err == nil {
+				// Rewards may be added to the stake at an era end so the increase is at least the delegated amount
+				expected := new(big.Int).Add(stakedBefore, big.NewInt(amount))
+
+				if stakedAfter.Cmp(expected) < 0 {
+					err = fmt.Errorf("delegated amount %s is less than the expected %s", stakedAfter.String(), expected.String())
+				}
+			}
+
+			return err
+		})
+
+	ctx.Step(`^the auction info bids show user-(\d+) delegating (\d+) fewer motes to validator node-(\d+)$`,
+		func(userId int, amount int64, nodeId int) error {
+			delegatorKey, validatorKey, err := getAuctionKeys(userId, nodeId)
+
+			var stakedAfter *big.Int
+			if err == nil {
+				stakedAfter, err = getDelegatedAmount(sdk, validatorKey.PublicKey(), delegatorKey.PublicKey())
+			}
+
+			if err == nil {
+				// Rewards may be added to the stake at an era end so the decrease is at most the undelegated amount
+				expected := new(big.Int).Sub(stakedBefore, big.NewInt(amount))
+
+				if stakedAfter.Cmp(expected) < 0 {
+					err = fmt.Errorf("delegated amount %s is less than the expected %s", stakedAfter.String(), expected.String())
+				} else if stakedAfter.Cmp(stakedBefore) >= 0 {
+					err = fmt.Errorf("delegated amount %s has not decreased from %s", stakedAfter.String(), stakedBefore.String())
+				}
+			}
+
+			return err
+		})
+
+	ctx.Step(`^user-(\d+) has an unbonding purse of (\d+) motes from validator node-(\d+)$`,
+		func(userId int, amount int64, nodeId int) error {
+			return assertUnbondingPurse(sdk, userId, amount, nodeId, 0, eraOfDeploy)
+		})
+
+	ctx.Step(`^user-(\d+) has an unbonding purse of (\d+) motes from validator node-(\d+) redelegated to validator node-(\d+)$`,
+		func(userId int, amount int64, nodeId int, newNodeId int) error {
+			return assertUnbondingPurse(sdk, userId, amount, nodeId, newNodeId, eraOfDeploy)
+		})
+
+	ctx.Step(`^the era following the auction deploy is reached within (\d+) seconds$`, func(timeout int) error {
+		_, err := utils.WaitForEra(eraOfDeploy+1, timeout)
+		return err
+	})
+}
+
+// getAuctionKeys loads the keys of a delegating user and a validator node
+func getAuctionKeys(userId int, nodeId int) (keypair.PrivateKey, keypair.PrivateKey, error) {
+	delegatorKey, err := casper.NewED25519PrivateKeyFromPEMFile(utils.GetUserKeyAssetPath(1, userId, "secret_key.pem"))
+	if err != nil {
+		return keypair.PrivateKey{}, keypair.PrivateKey{}, err
+	}
+
+	validatorKey, err := casper.NewED25519PrivateKeyFromPEMFile(utils.GetNodeKeyAssetPath(1, nodeId, "secret_key.pem"))
+
+	return delegatorKey, validatorKey, err
+}
+
+// getDelegatedAmount obtains the amount a delegator currently has staked with a validator from the latest auction info
+func getDelegatedAmount(sdk casper.RPCClient, validator keypair.PublicKey, delegator keypair.PublicKey) (*big.Int, error) {
+	auctionInfo, err := sdk.GetAuctionInfoLatest(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	if auctionDelegator := utils.FindAuctionDelegator(auctionInfo.AuctionState, validator, delegator); auctionDelegator != nil {
+		return auctionDelegator.StakedAmount.Value(), nil
+	}
+
+	return big.NewInt(0), nil
+}
+
+// assertUnbondingPurse checks the user has an unbonding purse created in the era for the amount unbonded from the
+// validator node and, when newNodeId is not 0, redelegated to the new validator node
+func assertUnbondingPurse(sdk casper.RPCClient, userId int, amount int64, nodeId int, newNodeId int, eraOfCreation uint32) error {
+	delegatorKey, validatorKey, err := getAuctionKeys(userId, nodeId)
+	if err != nil {
+		return err
+	}
+
+	var newValidator *keypair.PublicKey
+	if newNodeId != 0 {
+		newValidatorKey, err := casper.NewED25519PrivateKeyFromPEMFile(utils.GetNodeKeyAssetPath(1, newNodeId, "secret_key.pem"))
+		if err != nil {
+			return err
+		}
+		newValidatorPublicKey := newValidatorKey.PublicKey()
+		newValidator = &newValidatorPublicKey
+	}
+
+	stateRootHash, err := sdk.GetStateRootHashLatest(context.Background())
+	if err != nil {
+		return err
+	}
+
+	unbondingPurses, err := utils.GetUnbondingPurses(stateRootHash.StateRootHash.String(), delegatorKey.PublicKey())
+	if err != nil {
+		return err
+	}
+
+	for _, purse := range unbondingPurses {
+		if !purse.ValidatorPublicKey.Equals(validatorKey.PublicKey()) ||
+			!purse.UnbonderPublicKey.Equals(delegatorKey.PublicKey()) ||
+			purse.EraOfCreation != eraOfCreation ||
+			purse.Amount.Value().Cmp(big.NewInt(amount)) != 0 {
+			continue
+		}
+
+		if newValidator == nil && purse.NewValidator == nil {
+			return utils.Pass
+		}
+
+		if newValidator != nil && purse.NewValidator != nil && purse.NewValidator.Equals(*newValidator) {
+			return utils.Pass
+		}
+	}
+
+	return fmt.Errorf("no unbonding purse of %d motes from node-%d created in era %d found for user-%d", amount, nodeId, eraOfCreation, userId)
+}
@@ -0,0 +1,145 @@
+package steps
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/make-software/casper-go-sdk/casper"
+	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/types/clvalue"
+	"github.com/make-software/casper-go-sdk/types/keypair"
+
+	"github.com/casper-sdks/terminus-go-tests/tests/utils"
+)
+
+// The test features implementation for the balance_accounting.feature
+func TestFeaturesBalanceAccounting(t *testing.T) {
+	utils.TestFeatures(t, "balance_accounting.feature", InitializeBalanceAccounting)
+}
+
+func InitializeBalanceAccounting(ctx *godog.ScenarioContext) {
+	var sdk casper.RPCClient
+	var senderKey keypair.PrivateKey
+	var receiverKey keypair.PrivateKey
+	var transferDeployResult rpc.PutDeployResult
+	var transferDeployInfo rpc.InfoGetDeployResult
+
+	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+		utils.ReadConfig()
+		sdk = utils.GetRPCClient()
+		return ctx, nil
+	})
+
+	ctx.Step(`^that user-(\d+) is the sender$`, func(userId int) error {
+		var err error
+		senderKey, err = casper.NewED25519PrivateKeyFromPEMFile(utils.GetUserKeyAssetPath(1, userId, "secret_key.pem"))
+		return err
+	})
+
+	ctx.Step(`^that user-(\d+) is the receiver$`, func(userId int) error {
+		var err error
+		receiverKey, err = casper.NewED25519PrivateKeyFromPEMFile(utils.GetUserKeyAssetPath(1, userId, "secret_key.pem"))
+		return err
+	})
+
+	ctx.Step(`^that a generated "([^"]*)" sender is funded with (\d+) motes from the faucet within (\d+) seconds$`,
+		func(keyAlgo string, amount int64, timeout int) error {
+			var err error
+			senderKey, err = generateKey(keyAlgo)
+			if err != nil {
+				return err
+			}
+
+			faucetKey, err := casper.NewED25519PrivateKeyFromPEMFile("../../assets/net-1/faucet/secret_key.pem")
+			if err != nil {
+				return err
+			}
+
+			payment, err := utils.GetWasmlessTransferCost()
+			if err != nil {
+				return err
+			}
+
+			deploy, err := utils.BuildTransferDeploy(faucetKey, clvalue.NewCLPublicKey(senderKey.PublicKey()), big.NewInt(amount), rand.Uint64(), payment)
+
+			var fundResult rpc.PutDeployResult
+			if err == nil {
+				fundResult, err = sdk.PutDeploy(context.Background(), *deploy)
+			}
+
+			var fundInfo rpc.InfoGetDeployResult
+			if err == nil {
+				fundInfo, err = utils.WaitForDeploy(fundResult.DeployHash.String(), timeout)
+			}
+
+			if err == nil && fundInfo.ExecutionResults[0].Result.Success == nil {
+				err = fmt.Errorf("funding deploy %s failed: %s", fundResult.DeployHash.String(),
+					fundInfo.ExecutionResults[0].Result.Failure.ErrorMessage)
+			}
+
+			return err
+		})
+
+	ctx.Step(`^the sender transfers (\d+) motes to the receiver by (public key|account hash|purse uref)$`,
+		func(amount int64, targetKind string) error {
+			target, err := utils.GetTransferTarget(targetKind, receiverKey.PublicKey())
+			if err != nil {
+				return err
+			}
+
+			payment, err := utils.GetWasmlessTransferCost()
+			if err != nil {
+				return err
+			}
+
+			deploy, err := utils.BuildTransferDeploy(senderKey, target, big.NewInt(amount), rand.Uint64(), payment)
+
+			if err == nil {
+				transferDeployResult, err = sdk.PutDeploy(context.Background(), *deploy)
+			}
+
+			return err
+		})
+
+	ctx.Step(`^the balance transfer is executed successfully within (\d+) seconds$`, func(timeout int) error {
+		var err error
+		transferDeployInfo, err = utils.WaitForDeploy(transferDeployResult.DeployHash.String(), timeout)
+
+		if err == nil && transferDeployInfo.ExecutionResults[0].Result.Success == nil {
+			err = fmt.Errorf("transfer deploy %s failed: %s", transferDeployResult.DeployHash.String(),
+				transferDeployInfo.ExecutionResults[0].Result.Failure.ErrorMessage)
+		}
+
+		return err
+	})
+
+	// The balances before the transfer are those in the state of the parent of the block the transfer was executed in
+	// so other blocks added while waiting for the transfer do not affect the accounting
+
+	ctx.Step(`^the receiver main purse balance increased by (\d+) motes in the execution block$`, func(amount int64) error {
+		change, err := utils.GetMainPurseBalanceChange(receiverKey.PublicKey(), transferDeployInfo.ExecutionResults[0].BlockHash.String())
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "receiver balance change", change.String(), big.NewInt(amount).String())
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the sender main purse balance decreased by (\d+) motes plus the charged cost in the execution block$`, func(amount int64) error {
+		change, err := utils.GetMainPurseBalanceChange(senderKey.PublicKey(), transferDeployInfo.ExecutionResults[0].BlockHash.String())
+
+		if err == nil {
+			cost := new(big.Int).SetUint64(transferDeployInfo.ExecutionResults[0].Result.Success.Cost)
+			expected := new(big.Int).Neg(new(big.Int).Add(big.NewInt(amount), cost))
+
+			err = utils.ExpectEqual(utils.CasperT, "sender balance change", change.String(), expected.String())
+		}
+
+		return err
+	})
+}
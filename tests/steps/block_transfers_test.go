@@ -0,0 +1,318 @@
+package steps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/make-software/casper-go-sdk/casper"
+	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/types"
+	"github.com/make-software/casper-go-sdk/types/clvalue"
+	"github.com/make-software/casper-go-sdk/types/keypair"
+
+	"github.com/casper-sdks/terminus-go-tests/tests/utils"
+)
+
+// A transfer submitted by the block_transfers.feature
+type submittedTransfer struct {
+	deployHash string
+	senderKey  keypair.PrivateKey
+	receiver   keypair.PublicKey
+	amount     *big.Int
+	id         uint64
+	blockHash  string
+}
+
+// A transfer record of the node's chain_get_block_transfers JSON, numbers are kept as text to avoid a loss of precision
+type nodeTransfer struct {
+	DeployHash string       `json:"deploy_hash"`
+	From       string       `json:"from"`
+	To         *string      `json:"to"`
+	Source     string       `json:"source"`
+	Target     string       `json:"target"`
+	Amount     string       `json:"amount"`
+	Gas        string       `json:"gas"`
+	ID         *json.Number `json:"id"`
+}
+
+// The test features implementation for the block_transfers.feature
+func TestFeaturesBlockTransfers(t *testing.T) {
+	utils.TestFeatures(t, "block_transfers.feature", InitializeBlockTransfers)
+}
+
+func InitializeBlockTransfers(ctx *godog.ScenarioContext) {
+	var sdk casper.RPCClient
+	var submitted []*submittedTransfer
+	var byHash map[string]rpc.ChainGetBlockTransfersResult
+	var byHeight map[string]rpc.ChainGetBlockTransfersResult
+
+	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+		utils.ReadConfig()
+		sdk = utils.GetRPCClient()
+		submitted = make([]*submittedTransfer, 0)
+		byHash = make(map[string]rpc.ChainGetBlockTransfersResult)
+		byHeight = make(map[string]rpc.ChainGetBlockTransfersResult)
+		return ctx, nil
+	})
+
+	ctx.Step(`^that user-(\d+) makes (\d+) transfers of (\d+) motes to user-(\d+)$`,
+		func(senderId int, count int, amount int64, receiverId int) error {
+			senderKey, err := casper.NewED25519PrivateKeyFromPEMFile(utils.GetUserKeyAssetPath(1, senderId, "secret_key.pem"))
+			if err != nil {
+				return err
+			}
+
+			receiverKey, err := casper.NewED25519PrivateKeyFromPEMFile(utils.GetUserKeyAssetPath(1, receiverId, "secret_key.pem"))
+			if err != nil {
+				return err
+			}
+
+			payment, err := utils.GetWasmlessTransferCost()
+			if err != nil {
+				return err
+			}
+
+			for i := 0; i < count; i++ {
+				transfer := &submittedTransfer{
+					senderKey: senderKey,
+					receiver:  receiverKey.PublicKey(),
+					amount:    big.NewInt(amount),
+					id:        rand.Uint64(),
+				}
+
+				deploy, err := utils.BuildTransferDeploy(senderKey, clvalue.NewCLPublicKey(transfer.receiver), transfer.amount, transfer.id, payment)
+				if err != nil {
+					return err
+				}
+
+				result, err := sdk.PutDeploy(context.Background(), *deploy)
+				if err != nil {
+					return err
+				}
+
+				transfer.deployHash = result.DeployHash.String()
+				submitted = append(submitted, transfer)
+			}
+
+			return utils.Pass
+		})
+
+	ctx.Step(`^the blocks containing the transfers are added within (\d+) seconds$`, func(timeout int) error {
+		for _, transfer := range submitted {
+			deploy, err := utils.WaitForDeploy(transfer.deployHash, timeout)
+			if err != nil {
+				return err
+			}
+
+			if deploy.ExecutionResults[0].Result.Success == nil {
+				return fmt.Errorf("transfer deploy %s failed: %s", transfer.deployHash, deploy.ExecutionResults[0].Result.Failure.ErrorMessage)
+			}
+
+			transfer.blockHash = deploy.ExecutionResults[0].BlockHash.String()
+		}
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^chain_get_block_transfers is requested via the sdk for each block by hash and by height$`, func() error {
+		for _, transfer := range submitted {
+			if _, ok := byHash[transfer.blockHash]; ok {
+				continue
+			}
+
+			block, err := sdk.GetBlockByHash(context.Background(), transfer.blockHash)
+			if err != nil {
+				return err
+			}
+
+			if byHash[transfer.blockHash], err = sdk.GetBlockTransfersByHash(context.Background(), transfer.blockHash); err != nil {
+				return err
+			}
+
+			if byHeight[transfer.blockHash], err = sdk.GetBlockTransfersByHeight(context.Background(), block.Block.Header.Height); err != nil {
+				return err
+			}
+		}
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^the block transfers by hash and by height are the same$`, func() error {
+		for blockHash, hashResult := range byHash {
+			heightResult := byHeight[blockHash]
+
+			err := utils.ExpectEqual(utils.CasperT, "block_hash", heightResult.BlockHash, hashResult.BlockHash)
+
+			if err == nil {
+				err = utils.ExpectEqual(utils.CasperT, "transfers", len(heightResult.Transfers), len(hashResult.Transfers))
+			}
+
+			for i := 0; err == nil && i < len(hashResult.Transfers); i++ {
+				err = compareTransfers(heightResult.Transfers[i], hashResult.Transfers[i])
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^every block transfer matches the node's chain_get_block_transfers JSON$`, func() error {
+		for blockHash, result := range byHash {
+			transfersJson, err := utils.GetBlockTransfersByHash(blockHash)
+			if err != nil {
+				return err
+			}
+
+			var nodeResult struct {
+				Result struct {
+					BlockHash string         `json:"block_hash"`
+					Transfers []nodeTransfer `json:"transfers"`
+				} `json:"result"`
+			}
+
+			if err = json.Unmarshal([]byte(transfersJson), &nodeResult); err != nil {
+				return err
+			}
+
+			err = utils.ExpectEqual(utils.CasperT, "block_hash", result.BlockHash, nodeResult.Result.BlockHash)
+
+			if err == nil {
+				err = utils.ExpectEqual(utils.CasperT, "transfers", len(result.Transfers), len(nodeResult.Result.Transfers))
+			}
+
+			for i := 0; err == nil && i < len(result.Transfers); i++ {
+				err = compareNodeTransfer(result.Transfers[i], nodeResult.Result.Transfers[i])
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^every submitted transfer is in its block's transfers with the submitted values$`, func() error {
+		for _, submittedTransfer := range submitted {
+			var transfer *types.Transfer
+
+			for i, blockTransfer := range byHash[submittedTransfer.blockHash].Transfers {
+				if blockTransfer.DeployHash.String() == submittedTransfer.deployHash {
+					transfer = &byHash[submittedTransfer.blockHash].Transfers[i]
+				}
+			}
+
+			if transfer == nil {
+				return fmt.Errorf("transfer deploy %s not found in block %s", submittedTransfer.deployHash, submittedTransfer.blockHash)
+			}
+
+			sourcePurse, err := getMainPurse(sdk, submittedTransfer.senderKey.PublicKey(), submittedTransfer.blockHash)
+			if err != nil {
+				return err
+			}
+
+			targetPurse, err := getMainPurse(sdk, submittedTransfer.receiver, submittedTransfer.blockHash)
+			if err != nil {
+				return err
+			}
+
+			err = utils.ExpectEqual(utils.CasperT, "from",
+				transfer.From.ToPrefixedString(),
+				submittedTransfer.senderKey.PublicKey().AccountHash().ToPrefixedString())
+
+			if err == nil {
+				err = expectTransferTo(transfer.To, submittedTransfer.receiver)
+			}
+
+			if err == nil {
+				err = expectSamePurse(transfer.Source, sourcePurse)
+			}
+
+			if err == nil {
+				err = expectSamePurse(transfer.Target, targetPurse)
+			}
+
+			if err == nil {
+				err = utils.ExpectEqual(utils.CasperT, "amount", transfer.Amount.Value().String(), submittedTransfer.amount.String())
+			}
+
+			if err == nil {
+				err = utils.ExpectEqual(utils.CasperT, "id", transfer.ID, submittedTransfer.id)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return utils.Pass
+	})
+}
+
+// compareTransfers compares every field of two SDK transfer records
+func compareTransfers(actual types.Transfer, expected types.Transfer) error {
+	actualJson, err := json.Marshal(actual)
+	if err != nil {
+		return err
+	}
+
+	expectedJson, err := json.Marshal(expected)
+	if err != nil {
+		return err
+	}
+
+	return utils.ExpectEqual(utils.CasperT, "transfer", string(actualJson), string(expectedJson))
+}
+
+// compareNodeTransfer compares every field of an SDK transfer record with the node's JSON transfer record
+func compareNodeTransfer(transfer types.Transfer, expected nodeTransfer) error {
+	err := utils.ExpectEqual(utils.CasperT, "deploy_hash", transfer.DeployHash.String(), expected.DeployHash)
+
+	if err == nil {
+		err = utils.ExpectEqual(utils.CasperT, "from", transfer.From.ToPrefixedString(), expected.From)
+	}
+
+	if err == nil {
+		err = utils.ExpectEqual(utils.CasperT, "to", transfer.To == nil, expected.To == nil)
+	}
+
+	if err == nil && expected.To != nil {
+		err = utils.ExpectEqual(utils.CasperT, "to", transfer.To.ToPrefixedString(), *expected.To)
+	}
+
+	if err == nil {
+		err = utils.ExpectEqual(utils.CasperT, "source", transfer.Source.String(), expected.Source)
+	}
+
+	if err == nil {
+		err = utils.ExpectEqual(utils.CasperT, "target", transfer.Target.String(), expected.Target)
+	}
+
+	if err == nil {
+		err = utils.ExpectEqual(utils.CasperT, "amount", transfer.Amount.Value().String(), expected.Amount)
+	}
+
+	if err == nil {
+		err = utils.ExpectEqual(utils.CasperT, "gas", strconv.FormatUint(uint64(transfer.Gas), 10), expected.Gas)
+	}
+
+	if err == nil {
+		// The SDK does not distinguish a missing id from an id of 0
+		expectedID := "0"
+		if expected.ID != nil {
+			expectedID = expected.ID.String()
+		}
+		err = utils.ExpectEqual(utils.CasperT, "id", strconv.FormatUint(transfer.ID, 10), expectedID)
+	}
+
+	return err
+}
@@ -2,11 +2,17 @@ package steps
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/cucumber/godog"
 	"github.com/make-software/casper-go-sdk/casper"
 	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/types"
+	"github.com/make-software/casper-go-sdk/types/keypair"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/casper-sdks/terminus-go-tests/tests/utils"
@@ -15,6 +21,10 @@ import (
 type _map struct {
 	blockDataNode casper.Block
 	blockDataSdk  rpc.ChainGetBlockResult
+	signedWeight  *big.Rat
+	blockByHeight rpc.ChainGetBlockResult
+	blockByHash   rpc.ChainGetBlockResult
+	switchBlock   types.Block
 }
 
 var contextMap _map
@@ -82,4 +92,224 @@ func InitializeBlocksScenario(ctx *godog.ScenarioContext) {
 		)
 		return err
 	})
+
+	ctx.Step(`^the proofs of the returned block are valid signatures by the era validators$`, func() error {
+		block := contextMap.blockDataSdk.Block
+
+		weights, err := utils.GetEraValidatorWeights(block)
+
+		if err == nil {
+			contextMap.signedWeight, err = utils.VerifyBlockProofs(block, weights)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the proofs of the returned block are signed by more than (\d+)% of the era validator weight$`, func(percent int64) error {
+		threshold := big.NewRat(percent, 100)
+
+		if contextMap.signedWeight.Cmp(threshold) <= 0 {
+			return fmt.Errorf("signed weight fraction %s does not exceed %d%%", contextMap.signedWeight.FloatString(4), percent)
+		}
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^the hash and body hash of the returned block match the recomputed hashes$`, func() error {
+		return utils.VerifyBlockHashes(contextMap.blockDataSdk.Block)
+	})
+
+	ctx.Step(`^the hash and body hash of the last switch block match the recomputed hashes$`, func() error {
+		eraID := contextMap.blockDataSdk.Block.Header.EraID
+		if eraID == 0 {
+			return errors.New("no switch block exists before era 1")
+		}
+
+		switchBlock, err := utils.GetSwitchBlock(eraID - 1)
+
+		if err == nil {
+			err = utils.VerifyBlockHashes(switchBlock)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^that the block (\d+) below the latest block is requested via the sdk by height$`, func(depth uint64) error {
+		sdk := utils.GetRPCClient()
+
+		latest, err := sdk.GetBlockLatest(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if latest.Block.Header.Height < depth {
+			return fmt.Errorf("the latest block height %d is below %d", latest.Block.Header.Height, depth)
+		}
+
+		contextMap.blockByHeight, err = sdk.GetBlockByHeight(context.Background(), latest.Block.Header.Height-depth)
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "height", contextMap.blockByHeight.Block.Header.Height, latest.Block.Header.Height-depth)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the block with the hash of the block by height is requested via the sdk by hash$`, func() error {
+		var err error
+		contextMap.blockByHash, err = utils.GetRPCClient().GetBlockByHash(context.Background(), contextMap.blockByHeight.Block.Hash.String())
+		return err
+	})
+
+	ctx.Step(`^the block by hash is equal to the block by height$`, func() error {
+		return utils.AssertExpectedAndActual(assert.Equal, contextMap.blockByHeight.Block, contextMap.blockByHash.Block)
+	})
+
+	ctx.Step(`^the block by height is equal to the test node block with the same hash$`, func() error {
+		block, err := utils.GetBlock(contextMap.blockByHeight.Block.Hash.String())
+		if err != nil {
+			return err
+		}
+
+		err = utils.AssertExpectedAndActual(assert.Equal, contextMap.blockByHeight.Block.Hash, block.Hash)
+
+		if err == nil {
+			err = utils.AssertExpectedAndActual(assert.Equal, contextMap.blockByHeight.Block.Header, block.Header)
+		}
+
+		if err == nil {
+			err = utils.AssertExpectedAndActual(assert.Equal, contextMap.blockByHeight.Block.Body, block.Body)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^that the last switch block is found by walking back from the latest block via the sdk$`, func() error {
+		sdk := utils.GetRPCClient()
+
+		result, err := sdk.GetBlockLatest(context.Background())
+
+		for err == nil && result.Block.Header.EraEnd == nil {
+			if result.Block.Header.Height == 0 {
+				return errors.New("no switch block found before the genesis block")
+			}
+			result, err = sdk.GetBlockByHash(context.Background(), result.Block.Header.ParentHash.String())
+		}
+
+		contextMap.switchBlock = result.Block
+
+		return err
+	})
+
+	ctx.Step(`^the switch block is the last block of its era$`, func() error {
+		latest, err := utils.GetRPCClient().GetBlockLatest(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if latest.Block.Header.Height == contextMap.switchBlock.Header.Height {
+			// The switch block is the latest block so the next era has not started
+			return utils.Pass
+		}
+
+		next, err := utils.GetRPCClient().GetBlockByHeight(context.Background(), contextMap.switchBlock.Header.Height+1)
+		if err != nil {
+			return err
+		}
+
+		return utils.ExpectEqual(utils.CasperT, "next block era", next.Block.Header.EraID, contextMap.switchBlock.Header.EraID+1)
+	})
+
+	ctx.Step(`^the switch block era_end is equal to the era_end of the test node switch block$`, func() error {
+		block, err := utils.GetBlock(contextMap.switchBlock.Hash.String())
+
+		if err == nil && block.Header.EraEnd == nil {
+			err = fmt.Errorf("the test node block %s has no era_end", block.Hash.String())
+		}
+
+		if err == nil {
+			err = utils.AssertExpectedAndActual(assert.Equal, contextMap.switchBlock.Header.EraEnd, block.Header.EraEnd)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the switch block era report rewards, equivocators and inactive validators are era validators$`, func() error {
+		weights, err := utils.GetEraValidatorWeights(contextMap.switchBlock)
+		if err != nil {
+			return err
+		}
+
+		eraReport := contextMap.switchBlock.Header.EraEnd.EraReport
+
+		for _, reward := range eraReport.Rewards {
+			if _, ok := weights[reward.Validator.ToHex()]; !ok {
+				return fmt.Errorf("reward validator %s is not a validator of era %d", reward.Validator.ToHex(), contextMap.switchBlock.Header.EraID)
+			}
+		}
+
+		for _, validators := range [][]keypair.PublicKey{eraReport.Equivocators, eraReport.InactiveValidators} {
+			for _, validator := range validators {
+				if _, ok := weights[validator.ToHex()]; !ok {
+					return fmt.Errorf("reported validator %s is not a validator of era %d", validator.ToHex(), contextMap.switchBlock.Header.EraID)
+				}
+			}
+		}
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^the switch block next era validator weights are equal to the next era validators of the auction info$`, func() error {
+		nextWeights, err := utils.GetNextEraValidatorWeights(contextMap.switchBlock)
+		if err != nil {
+			return err
+		}
+
+		auctionInfo, err := utils.GetRPCClient().GetAuctionInfoByHash(context.Background(), contextMap.switchBlock.Hash.String())
+		if err != nil {
+			return err
+		}
+
+		nextEraID := contextMap.switchBlock.Header.EraID + 1
+
+		for _, eraValidators := range auctionInfo.AuctionState.EraValidators {
+			if eraValidators.EraID != nextEraID {
+				continue
+			}
+
+			err = utils.ExpectEqual(utils.CasperT, "validators", len(eraValidators.ValidatorWeights), len(nextWeights))
+
+			for i := 0; err == nil && i < len(eraValidators.ValidatorWeights); i++ {
+				validatorWeight := eraValidators.ValidatorWeights[i]
+				weight, ok := nextWeights[validatorWeight.Validator.ToHex()]
+
+				if !ok {
+					err = fmt.Errorf("auction validator %s is not in the next era validator weights", validatorWeight.Validator.ToHex())
+				} else {
+					err = utils.ExpectEqual(utils.CasperT, "weight", validatorWeight.Weight.Value().String(), weight.String())
+				}
+			}
+
+			return err
+		}
+
+		return fmt.Errorf("era %d validators not found in the auction info", nextEraID)
+	})
+
+	// The errors of the block requests are asserted with the "the call fails with <name>" step
+
+	ctx.Step(`^a block is requested via the sdk by a height (\d+) above the latest block$`, func(ctx context.Context, increment uint64) (context.Context, error) {
+		latest, err := utils.GetRPCClient().GetBlockLatest(context.Background())
+		if err != nil {
+			return ctx, err
+		}
+
+		_, err = utils.GetRPCClient().GetBlockByHeight(context.Background(), latest.Block.Header.Height+increment)
+		return utils.WithRpcCall(ctx, "chain_get_block", err), utils.Pass
+	})
+
+	ctx.Step(`^a block is requested via the sdk by an unknown hash$`, func(ctx context.Context) (context.Context, error) {
+		_, err := utils.GetRPCClient().GetBlockByHash(context.Background(), strings.Repeat("0f", 32))
+		return utils.WithRpcCall(ctx, "chain_get_block", err), utils.Pass
+	})
 }
@@ -0,0 +1,55 @@
+package steps
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cucumber/godog"
+
+	"github.com/casper-sdks/terminus-go-tests/tests/utils"
+)
+
+// The test features implementation for the chain_integrity.feature
+func TestFeaturesChainIntegrity(t *testing.T) {
+	utils.TestFeatures(t, "chain_integrity.feature", InitializeChainIntegrity)
+}
+
+func InitializeChainIntegrity(ctx *godog.ScenarioContext) {
+	var report utils.ChainIntegrityReport
+
+	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+		utils.ReadConfig()
+		return ctx, nil
+	})
+
+	ctx.Step(`^that every block from the genesis block to the latest block is walked via the sdk$`, func() error {
+		var err error
+		report, err = utils.WalkChainToTip()
+		return err
+	})
+
+	ctx.Step(`^that every block from height (\d+) to height (\d+) is walked via the sdk$`, func(fromHeight uint64, toHeight uint64) error {
+		var err error
+		report, err = utils.WalkChain(fromHeight, toHeight)
+		return err
+	})
+
+	ctx.Step(`^every walked block was decoded$`, func() error {
+		if len(report.DecodeFailures) > 0 {
+			return fmt.Errorf("%d blocks could not be decoded at the heights %v", len(report.DecodeFailures), report.DecodeFailures)
+		}
+		return utils.ExpectEqual(utils.CasperT, "blocks", report.Blocks, report.ToHeight-report.FromHeight+1)
+	})
+
+	ctx.Step(`^the walked chain has no integrity violations$`, func() error {
+		return report.Err()
+	})
+
+	ctx.Step(`^the walked chain contains at least (\d+) switch blocks$`, func(count uint64) error {
+		if report.SwitchBlocks < count {
+			return fmt.Errorf("the walked chain has %d switch blocks, expected at least %d", report.SwitchBlocks, count)
+		}
+		return utils.Pass
+	})
+}
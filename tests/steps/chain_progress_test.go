@@ -0,0 +1,87 @@
+package steps
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/make-software/casper-go-sdk/casper"
+
+	"github.com/casper-sdks/terminus-go-tests/tests/utils"
+)
+
+// The test features implementation for the chain_progress.feature
+func TestFeaturesChainProgress(t *testing.T) {
+	utils.TestFeatures(t, "chain_progress.feature", InitializeChainProgress)
+}
+
+func InitializeChainProgress(ctx *godog.ScenarioContext) {
+	var sdk casper.RPCClient
+	var latestBlock casper.Block
+	var waitedBlock casper.Block
+	var signatureCount int
+
+	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+		utils.ReadConfig()
+		sdk = utils.GetRPCClient()
+		return ctx, nil
+	})
+
+	ctx.Step(`^that the latest block is obtained$`, func() error {
+		latest, err := sdk.GetBlockLatest(context.Background())
+		latestBlock = latest.Block
+		return err
+	})
+
+	ctx.Step(`^the chain reaches a block height (\d+) above the latest block within (\d+) seconds$`, func(increment uint64, timeout int) error {
+		var err error
+		waitedBlock, err = utils.WaitForBlockHeight(latestBlock.Header.Height+increment, timeout)
+
+		if err == nil && waitedBlock.Header.Height < latestBlock.Header.Height+increment {
+			err = fmt.Errorf("block height %d is below %d", waitedBlock.Header.Height, latestBlock.Header.Height+increment)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the next switch block is added within (\d+) seconds$`, func(timeout int) error {
+		var err error
+		waitedBlock, err = utils.WaitForNextSwitchBlock(timeout)
+
+		if err == nil && waitedBlock.Header.EraEnd == nil {
+			err = fmt.Errorf("block %s is not a switch block", waitedBlock.Hash.String())
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the switch block ends the era of the latest block or a later era$`, func() error {
+		if waitedBlock.Header.EraID < latestBlock.Header.EraID {
+			return fmt.Errorf("switch block era %d is before era %d", waitedBlock.Header.EraID, latestBlock.Header.EraID)
+		}
+		return utils.Pass
+	})
+
+	ctx.Step(`^the chain reaches the era after the latest block within (\d+) seconds$`, func(timeout int) error {
+		var err error
+		waitedBlock, err = utils.WaitForEra(latestBlock.Header.EraID+1, timeout)
+
+		if err == nil && waitedBlock.Header.EraID <= latestBlock.Header.EraID {
+			err = fmt.Errorf("block era %d is not after era %d", waitedBlock.Header.EraID, latestBlock.Header.EraID)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the latest block receives (\d+) finality signatures within (\d+) seconds$`, func(count int, timeout int) error {
+		var err error
+		signatureCount, err = utils.WaitForFinalitySignatures(latestBlock.Hash.String(), count, timeout)
+
+		if err == nil && signatureCount < count {
+			err = fmt.Errorf("only %d of %d finality signatures received", signatureCount, count)
+		}
+
+		return err
+	})
+}
@@ -0,0 +1,135 @@
+package steps
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/make-software/casper-go-sdk/casper"
+	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/sse"
+	"github.com/make-software/casper-go-sdk/types"
+
+	"github.com/casper-sdks/terminus-go-tests/tests/utils"
+)
+
+// The test features implementation for the deploy_expiry.feature
+func TestFeaturesDeployExpiry(t *testing.T) {
+	utils.TestFeatures(t, "deploy_expiry.feature", InitializeDeployExpiry)
+}
+
+func InitializeDeployExpiry(ctx *godog.ScenarioContext) {
+	var sdk casper.RPCClient
+	var expiringDeploy *types.Deploy
+	var expiringDeployResult rpc.PutDeployResult
+	var expiredAt time.Time
+
+	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+		utils.ReadConfig()
+		sdk = utils.GetRPCClient()
+		return ctx, nil
+	})
+
+	putExpiringDeploy := func(ttl time.Duration, timestamp time.Time) error {
+		var err error
+		expiringDeploy, err = utils.BuildExpiringTransferDeploy(ttl, timestamp)
+
+		if err == nil {
+			expiringDeployResult, err = sdk.PutDeploy(context.Background(), *expiringDeploy)
+		}
+
+		return err
+	}
+
+	ctx.Step(`^that a transfer deploy with an unmet dependency and a ttl of (\d+) seconds is put$`, func(ttl int) error {
+		return putExpiringDeploy(time.Duration(ttl)*time.Second, time.Now())
+	})
+
+	ctx.Step(`^that a transfer deploy with an unmet dependency, a ttl of (\d+) seconds and a timestamp (\d+) seconds in the past is put$`,
+		func(ttl int, age int) error {
+			return putExpiringDeploy(time.Duration(ttl)*time.Second, time.Now().Add(-time.Duration(age)*time.Second))
+		})
+
+	ctx.Step(`^a DeployExpired event is received for the expiring deploy within (\d+) seconds$`, func(timeout int) error {
+		event, err := utils.WaitForEvent("main", sse.DeployExpiredEventType, timeout, func(event sse.RawEvent) bool {
+			expired, err := event.ParseAsDeployExpiredEvent()
+			return err == nil && expired.DeployExpired.DeployHash.String() == expiringDeployResult.DeployHash.String()
+		})
+
+		var expired sse.DeployExpiredEvent
+		if err == nil {
+			expiredAt = time.Now()
+			expired, err = event.ParseAsDeployExpiredEvent()
+		}
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "deploy_hash", expired.DeployExpired.DeployHash.String(), expiringDeployResult.DeployHash.String())
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the expiring deploy expired after its timestamp plus its ttl$`, func() error {
+		expiry := time.Time(expiringDeploy.Header.Timestamp).Add(time.Duration(expiringDeploy.Header.TTL))
+
+		if expiredAt.Before(expiry) {
+			return fmt.Errorf("deploy %s expired at %v before its expiry time %v", expiringDeployResult.DeployHash.String(), expiredAt, expiry)
+		}
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^the info_get_deploy result of the expiring deploy has no execution results$`, func() error {
+		deploy, err := sdk.GetDeploy(context.Background(), expiringDeployResult.DeployHash.String())
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "execution_results", len(deploy.ExecutionResults), 0)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the info_get_deploy result of the expiring deploy has the submitted ttl, timestamp and dependencies$`, func() error {
+		deploy, err := sdk.GetDeploy(context.Background(), expiringDeployResult.DeployHash.String())
+		if err != nil {
+			return err
+		}
+
+		header := deploy.Deploy.Header
+
+		err = utils.VerifyDeployHashes(deploy.Deploy)
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "ttl", time.Duration(header.TTL), time.Duration(expiringDeploy.Header.TTL))
+		}
+
+		if err == nil {
+			// Timestamps are serialised with millisecond precision
+			err = utils.ExpectEqual(utils.CasperT, "timestamp",
+				time.Time(header.Timestamp).UnixMilli(),
+				time.Time(expiringDeploy.Header.Timestamp).UnixMilli())
+		}
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "dependencies", len(header.Dependencies), len(expiringDeploy.Header.Dependencies))
+		}
+
+		for i := 0; err == nil && i < len(header.Dependencies); i++ {
+			err = utils.ExpectEqual(utils.CasperT, "dependency", header.Dependencies[i].String(), expiringDeploy.Header.Dependencies[i].String())
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the expiring deploy was never included in a block$`, func() error {
+		tracked, ok := utils.GetDeployTracker().Get(expiringDeployResult.DeployHash.String())
+
+		if ok && tracked.Reached(utils.DeployIncluded) {
+			return fmt.Errorf("expired deploy %s was included in block %s", tracked.Hash, tracked.BlockHash)
+		}
+
+		return utils.Pass
+	})
+}
@@ -0,0 +1,109 @@
+package steps
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/make-software/casper-go-sdk/casper"
+	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/types"
+
+	"github.com/casper-sdks/terminus-go-tests/tests/utils"
+)
+
+// The test features implementation for the deploy_lifecycle.feature
+func TestFeaturesDeployLifecycle(t *testing.T) {
+	utils.TestFeatures(t, "deploy_lifecycle.feature", InitializeDeployLifecycle)
+}
+
+func InitializeDeployLifecycle(ctx *godog.ScenarioContext) {
+	var sdk casper.RPCClient
+	var trackedDeployResult rpc.PutDeployResult
+	var trackedDeploy utils.TrackedDeploy
+
+	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+		utils.ReadConfig()
+		sdk = utils.GetRPCClient()
+		return ctx, nil
+	})
+
+	ctx.Step(`^that a transfer deploy is put for lifecycle tracking$`, func() error {
+		deploy, err := utils.BuildStandardTransferDeploy(types.Args{})
+
+		if err == nil {
+			trackedDeployResult, err = sdk.PutDeploy(context.Background(), *deploy)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the tracked deploy is (put|accepted|included|processed|visible|expired) within (\d+) seconds$`, func(stateName string, timeout int) error {
+		state, err := utils.ParseDeployState(stateName)
+
+		if err == nil {
+			trackedDeploy, err = utils.GetDeployTracker().WaitForState(trackedDeployResult.DeployHash.String(), state, timeout)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the tracked deploy is visible via info_get_deploy within (\d+) seconds$`, func(timeout int) error {
+		_, err := utils.WaitForDeploy(trackedDeployResult.DeployHash.String(), timeout)
+
+		if err == nil {
+			trackedDeploy, err = utils.GetDeployTracker().WaitForState(trackedDeployResult.DeployHash.String(), utils.DeployVisible, 0)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the tracked deploy is included within (\d+) blocks of being put$`, func(blockCount uint64) error {
+		if !trackedDeploy.Reached(utils.DeployIncluded) {
+			return fmt.Errorf("deploy %s has not been included in a block", trackedDeploy.Hash)
+		}
+
+		if trackedDeploy.BlockHeight > trackedDeploy.PutHeight+blockCount {
+			return fmt.Errorf("deploy %s put at block height %d was included at block height %d, more than %d blocks later",
+				trackedDeploy.Hash, trackedDeploy.PutHeight, trackedDeploy.BlockHeight, blockCount)
+		}
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^the tracked deploy was included in the block of its info_get_deploy execution result$`, func() error {
+		deploy, err := sdk.GetDeploy(context.Background(), trackedDeploy.Hash)
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "block_hash", trackedDeploy.BlockHash, deploy.ExecutionResults[0].BlockHash.String())
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the tracked deploy states were reached in the order put, accepted, processed, visible$`, func() error {
+		states := []utils.DeployState{utils.DeployPut, utils.DeployAccepted, utils.DeployProcessed, utils.DeployVisible}
+
+		for i := 1; i < len(states); i++ {
+			latency, ok := trackedDeploy.Latency(states[i-1], states[i])
+
+			if !ok {
+				return fmt.Errorf("deploy %s has not reached both the %s and %s states", trackedDeploy.Hash, states[i-1], states[i])
+			}
+
+			if latency < 0 {
+				return fmt.Errorf("deploy %s was %s %v before it was %s", trackedDeploy.Hash, states[i], -latency, states[i-1])
+			}
+		}
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^the tracked deploy has not expired$`, func() error {
+		if trackedDeploy.Reached(utils.DeployExpired) {
+			return fmt.Errorf("deploy %s expired", trackedDeploy.Hash)
+		}
+		return utils.Pass
+	})
+}
@@ -0,0 +1,170 @@
+package steps
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/make-software/casper-go-sdk/casper"
+	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/types"
+
+	"github.com/casper-sdks/terminus-go-tests/tests/utils"
+)
+
+// The test features implementation for the deploy_rejections.feature
+func TestFeaturesDeployRejections(t *testing.T) {
+	utils.TestFeatures(t, "deploy_rejections.feature", InitializeDeployRejections)
+}
+
+func InitializeDeployRejections(ctx *godog.ScenarioContext) {
+	var sdk casper.RPCClient
+	var rejectedDeploy *types.Deploy
+	var rejectedDeployResult rpc.PutDeployResult
+	var putErr error
+	var rpcErr utils.RpcErrorResult
+
+	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+		utils.ReadConfig()
+		sdk = utils.GetRPCClient()
+		putErr = nil
+		rpcErr = utils.RpcErrorResult{}
+		return ctx, nil
+	})
+
+	ctx.Step(`^that a transfer deploy with the "([^"]*)" defect is put$`, func(defect string) error {
+		var err error
+		rejectedDeploy, err = utils.BuildStandardTransferDeploy(types.Args{})
+		if err != nil {
+			return err
+		}
+
+		if defect == "duplicate submission" {
+			// The first submission is valid, the second is the one under test
+			if _, err = sdk.PutDeploy(context.Background(), *rejectedDeploy); err != nil {
+				return err
+			}
+		} else if err = applyDeployDefect(rejectedDeploy, defect); err != nil {
+			return err
+		}
+
+		rejectedDeployResult, putErr = sdk.PutDeploy(context.Background(), *rejectedDeploy)
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^the account_put_deploy call is rejected$`, func() error {
+		if putErr == nil {
+			return fmt.Errorf("deploy %s was accepted", rejectedDeployResult.DeployHash.String())
+		}
+
+		rpcErr = utils.InspectRpcError(putErr)
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^the account_put_deploy error code is (-?\d+)$`, func(code int) error {
+		return rpcErr.ExpectCode(code)
+	})
+
+	ctx.Step(`^the account_put_deploy error message is "([^"]*)"$`, func(message string) error {
+		return rpcErr.ExpectMessage(message)
+	})
+
+	ctx.Step(`^the account_put_deploy error data contains "([^"]*)"$`, func(data string) error {
+		if !strings.Contains(string(rpcErr.RpcError.Data), data) {
+			return fmt.Errorf("error data %s does not contain %s", string(rpcErr.RpcError.Data), data)
+		}
+		return utils.Pass
+	})
+
+	ctx.Step(`^the account_put_deploy call returns the deploy hash$`, func() error {
+		if putErr != nil {
+			return putErr
+		}
+		return utils.ExpectEqual(utils.CasperT, "deploy_hash", rejectedDeployResult.DeployHash.String(), rejectedDeploy.Hash.String())
+	})
+}
+
+// applyDeployDefect alters a signed deploy so that it will be rejected by the node for the defect
+func applyDeployDefect(deploy *types.Deploy, defect string) error {
+	senderKey, err := casper.NewED25519PrivateKeyFromPEMFile(utils.GetUserKeyAssetPath(1, 1, "secret_key.pem"))
+	if err != nil {
+		return err
+	}
+
+	header := deploy.Header
+	session := deploy.Session
+
+	switch defect {
+	case "wrong chain name":
+		header.ChainName = "casper-wrong-chain"
+
+	case "future timestamp":
+		header.Timestamp = types.Timestamp(time.Now().Add(time.Hour))
+
+	case "excessive ttl":
+		maxTtl, err := utils.GetMaxTtl()
+		if err != nil {
+			return err
+		}
+		header.TTL = types.Duration(maxTtl + time.Hour)
+
+	case "oversize session":
+		maxDeploySize, err := utils.GetMaxDeploySize()
+		if err != nil {
+			return err
+		}
+
+		moduleBytes := make([]byte, maxDeploySize+1)
+		if _, err = rand.Read(moduleBytes); err != nil {
+			return err
+		}
+
+		session = types.ExecutableDeployItem{
+			ModuleBytes: &types.ModuleBytes{
+				ModuleBytes: hex.EncodeToString(moduleBytes),
+				Args:        &types.Args{},
+			},
+		}
+
+	case "bad body hash":
+		// The deploy hash and approval are recomputed so only the body hash is invalid
+		if _, err = rand.Read(deploy.Header.BodyHash[:]); err != nil {
+			return err
+		}
+		deploy.Hash = utils.ComputeDeployHash(*deploy)
+		deploy.Approvals = nil
+		return deploy.SignDeploy(senderKey)
+
+	case "tampered signature":
+		signature := deploy.Approvals[0].Signature
+		signature[len(signature)-1] ^= 0xff
+		return nil
+
+	case "missing approval":
+		deploy.Approvals = []types.Approval{}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown deploy defect %s", defect)
+	}
+
+	altered, err := types.MakeDeploy(header, deploy.Payment, session)
+	if err != nil {
+		return err
+	}
+
+	if err = altered.SignDeploy(senderKey); err != nil {
+		return err
+	}
+
+	*deploy = *altered
+
+	return nil
+}
@@ -233,6 +233,17 @@ func InitializeDeploys(ctx *godog.ScenarioContext) {
 		}
 		return utils.Pass
 	})
+
+	ctx.Step(`^the deploy hash and body hash match the recomputed hashes$`, func() error {
+		err := utils.VerifyDeployHashes(putDeploy)
+
+		if err == nil {
+			err = utils.VerifyDeployHashes(infoGetDeployResult.Deploy)
+		}
+
+		return err
+	})
+
 	ctx.Step(`^the deploy has a valid body hash$`, func() error {
 		return utils.ExpectEqual(utils.CasperT, "body hash",
 			infoGetDeployResult.Deploy.Header.BodyHash.String(),
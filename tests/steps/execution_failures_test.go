@@ -0,0 +1,217 @@
+package steps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/make-software/casper-go-sdk/casper"
+	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/sse"
+	"github.com/make-software/casper-go-sdk/types"
+	"github.com/make-software/casper-go-sdk/types/clvalue"
+	"github.com/make-software/casper-go-sdk/types/keypair"
+
+	"github.com/casper-sdks/terminus-go-tests/tests/utils"
+)
+
+// The test features implementation for the execution_failures.feature
+func TestFeaturesExecutionFailures(t *testing.T) {
+	utils.TestFeatures(t, "execution_failures.feature", InitializeExecutionFailures)
+}
+
+func InitializeExecutionFailures(ctx *godog.ScenarioContext) {
+	var sdk casper.RPCClient
+	var failedDeployResult rpc.PutDeployResult
+	var failedDeployInfo rpc.InfoGetDeployResult
+	var failure types.ExecutionResultStatusData
+	var paymentAmount *big.Int
+
+	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+		utils.ReadConfig()
+		sdk = utils.GetRPCClient()
+		return ctx, nil
+	})
+
+	ctx.Step(`^that user-(\d+) deploys a transfer of more than its balance to user-(\d+)$`, func(senderId int, receiverId int) error {
+		senderKey, err := casper.NewED25519PrivateKeyFromPEMFile(utils.GetUserKeyAssetPath(1, senderId, "secret_key.pem"))
+		if err != nil {
+			return err
+		}
+
+		receiverKey, err := casper.NewED25519PrivateKeyFromPEMFile(utils.GetUserKeyAssetPath(1, receiverId, "secret_key.pem"))
+		if err != nil {
+			return err
+		}
+
+		latest, err := sdk.GetBlockLatest(context.Background())
+		if err != nil {
+			return err
+		}
+
+		balance, err := utils.GetMainPurseBalanceAtBlock(senderKey.PublicKey(), latest.Block.Hash.String())
+		if err != nil {
+			return err
+		}
+
+		amount := new(big.Int).Add(balance, big.NewInt(1))
+
+		paymentAmount, err = utils.GetWasmlessTransferCost()
+		if err != nil {
+			return err
+		}
+
+		deploy, err := utils.BuildTransferDeploy(senderKey, clvalue.NewCLPublicKey(receiverKey.PublicKey()), amount, rand.Uint64(), paymentAmount)
+
+		if err == nil {
+			failedDeployResult, err = sdk.PutDeploy(context.Background(), *deploy)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^that user-(\d+) deploys a call to the auction entry point "([^"]*)" with a payment of (\d+) motes$`,
+		func(userId int, entryPoint string, payment int64) error {
+			senderKey, err := casper.NewED25519PrivateKeyFromPEMFile(utils.GetUserKeyAssetPath(1, userId, "secret_key.pem"))
+			if err != nil {
+				return err
+			}
+
+			paymentAmount = big.NewInt(payment)
+
+			deploy, err := buildAuctionCallDeploy(senderKey, entryPoint, paymentAmount)
+
+			if err == nil {
+				failedDeployResult, err = sdk.PutDeploy(context.Background(), *deploy)
+			}
+
+			return err
+		})
+
+	ctx.Step(`^the failed deploy is executed within (\d+) seconds$`, func(timeout int) error {
+		var err error
+		failedDeployInfo, err = utils.WaitForDeploy(failedDeployResult.DeployHash.String(), timeout)
+		if err != nil {
+			return err
+		}
+
+		result := failedDeployInfo.ExecutionResults[0].Result
+
+		if result.Failure == nil {
+			return fmt.Errorf("deploy %s was expected to fail but succeeded", failedDeployResult.DeployHash.String())
+		}
+
+		failure = *result.Failure
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^the info_get_deploy Failure error message contains "([^"]*)"$`, func(message string) error {
+		if !strings.Contains(failure.ErrorMessage, message) {
+			return fmt.Errorf("error message %s does not contain %s", failure.ErrorMessage, message)
+		}
+		return utils.Pass
+	})
+
+	ctx.Step(`^the info_get_deploy Failure cost is greater than zero and at most the payment amount$`, func() error {
+		cost := new(big.Int).SetUint64(failure.Cost)
+
+		if cost.Sign() <= 0 || cost.Cmp(paymentAmount) > 0 {
+			return fmt.Errorf("cost %s is not greater than zero and at most the payment amount %s", cost.String(), paymentAmount.String())
+		}
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^the info_get_deploy Failure cost equals the payment amount$`, func() error {
+		return utils.ExpectEqual(utils.CasperT, "cost", new(big.Int).SetUint64(failure.Cost).String(), paymentAmount.String())
+	})
+
+	ctx.Step(`^the info_get_deploy Failure has no transfers$`, func() error {
+		return utils.ExpectEqual(utils.CasperT, "transfers", len(failure.Transfers), 0)
+	})
+
+	ctx.Step(`^the info_get_deploy Failure effects include transforms$`, func() error {
+		if len(failure.Effect.Transforms) == 0 {
+			return errors.New("the failure effect has no transforms")
+		}
+		return utils.Pass
+	})
+
+	ctx.Step(`^the main purse balance of user-(\d+) decreased by (the Failure cost|the payment amount) in the execution block$`,
+		func(userId int, charge string) error {
+			userKey, err := casper.NewED25519PrivateKeyFromPEMFile(utils.GetUserKeyAssetPath(1, userId, "secret_key.pem"))
+			if err != nil {
+				return err
+			}
+
+			change, err := utils.GetMainPurseBalanceChange(userKey.PublicKey(), failedDeployInfo.ExecutionResults[0].BlockHash.String())
+			if err != nil {
+				return err
+			}
+
+			expected := new(big.Int).Set(paymentAmount)
+			if charge == "the Failure cost" {
+				expected.SetUint64(failure.Cost)
+			}
+
+			return utils.ExpectEqual(utils.CasperT, "balance change", change.String(), new(big.Int).Neg(expected).String())
+		})
+
+	ctx.Step(`^the DeployProcessed event for the failed deploy has the same Failure within (\d+) seconds$`, func(timeout int) error {
+		event, err := utils.WaitForEvent("main", sse.DeployProcessedEventType, timeout, func(event sse.RawEvent) bool {
+			processed, err := event.ParseAsDeployProcessedEvent()
+			return err == nil && processed.DeployProcessed.DeployHash.String() == failedDeployResult.DeployHash.String()
+		})
+
+		var processed sse.DeployProcessedEvent
+		if err == nil {
+			processed, err = event.ParseAsDeployProcessedEvent()
+		}
+
+		if err == nil && processed.DeployProcessed.ExecutionResult.Failure == nil {
+			err = fmt.Errorf("the DeployProcessed event of deploy %s is not a failure", failedDeployResult.DeployHash.String())
+		}
+
+		if err == nil {
+			err = compareExecutionResultData(*processed.DeployProcessed.ExecutionResult.Failure, failure)
+		}
+
+		return err
+	})
+}
+
+// buildAuctionCallDeploy builds a deploy calling an entry point of the auction contract without arguments
+func buildAuctionCallDeploy(senderKey keypair.PrivateKey, entryPoint string, paymentAmount *big.Int) (*types.Deploy, error) {
+	auctionHash, err := utils.GetSystemContractHash(utils.AuctionContractName)
+	if err != nil {
+		return nil, err
+	}
+
+	header := types.DefaultHeader()
+	header.ChainName = utils.GetChainName()
+	header.Account = senderKey.PublicKey()
+	header.Timestamp = types.Timestamp(time.Now())
+
+	session := types.ExecutableDeployItem{
+		StoredContractByHash: &types.StoredContractByHash{
+			Hash:       auctionHash,
+			EntryPoint: entryPoint,
+			Args:       &types.Args{},
+		},
+	}
+
+	deploy, err := types.MakeDeploy(header, types.StandardPayment(paymentAmount), session)
+
+	if err == nil {
+		err = deploy.SignDeploy(senderKey)
+	}
+
+	return deploy, err
+}
@@ -0,0 +1,191 @@
+package steps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/make-software/casper-go-sdk/casper"
+	"github.com/make-software/casper-go-sdk/sse"
+
+	"github.com/casper-sdks/terminus-go-tests/tests/utils"
+)
+
+// The test features implementation for the finality_signatures.feature
+func TestFeaturesFinalitySignatures(t *testing.T) {
+	utils.TestFeatures(t, "finality_signatures.feature", InitializeFinalitySignatures)
+}
+
+func InitializeFinalitySignatures(ctx *godog.ScenarioContext) {
+	var sdk casper.RPCClient
+	var addedBlocks []string
+	var signatures map[string][]sse.FinalitySignaturePayload
+
+	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+		utils.ReadConfig()
+		sdk = utils.GetRPCClient()
+		addedBlocks = make([]string, 0)
+		signatures = make(map[string][]sse.FinalitySignaturePayload)
+		return ctx, nil
+	})
+
+	ctx.Step(`^that FinalitySignature events are received for (\d+) added blocks within (\d+) seconds$`, func(blockCount int, timeout int) error {
+		var lock sync.Mutex
+
+		eventCtx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+		defer cancel()
+
+		// Blocks are added on the main stream and signatures on the sigs stream
+		mainClient := utils.GetSseStreamClient("main")
+		sigsClient := utils.GetSseStreamClient("sigs")
+
+		// Only blocks added after subscribing are tracked so that none of their signatures are missed
+		mainClient.RegisterHandler(sse.BlockAddedEventType, func(_ context.Context, event sse.RawEvent) error {
+			blockAdded, err := event.ParseAsBlockAddedEvent()
+			if err != nil {
+				return err
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			addedBlocks = append(addedBlocks, blockAdded.BlockAdded.BlockHash)
+
+			// Stop once the block after the last tracked block is added, allowing its signatures to arrive
+			if len(addedBlocks) > blockCount {
+				cancel()
+			}
+
+			return utils.Pass
+		})
+
+		sigsClient.RegisterHandler(sse.FinalitySignatureType, func(_ context.Context, event sse.RawEvent) error {
+			signature, err := event.ParseAsFinalitySignatureEvent()
+			if err != nil {
+				return err
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			blockHash := signature.FinalitySignature.BlockHash.String()
+			signatures[blockHash] = append(signatures[blockHash], signature.FinalitySignature)
+
+			return utils.Pass
+		})
+
+		var sigsErr error
+		var waitGroup sync.WaitGroup
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+			sigsErr = sigsClient.Start(eventCtx, -1)
+		}()
+
+		// Start without replaying the node's event buffer so only live events are received
+		err := mainClient.Start(eventCtx, -1)
+
+		// Stop listening to signatures once the main stream has finished
+		cancel()
+		waitGroup.Wait()
+
+		if err == nil || strings.Contains(err.Error(), "context") {
+			err = sigsErr
+		}
+
+		if len(addedBlocks) > blockCount {
+			addedBlocks = addedBlocks[:blockCount]
+			return utils.Pass
+		}
+
+		if err == nil || strings.Contains(err.Error(), "context") {
+			err = fmt.Errorf("only %d of %d blocks were added", len(addedBlocks), blockCount)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^each added block has FinalitySignature events$`, func() error {
+		for _, blockHash := range addedBlocks {
+			if len(signatures[blockHash]) == 0 {
+				return fmt.Errorf("no finality signatures received for block %s", blockHash)
+			}
+		}
+		return utils.Pass
+	})
+
+	ctx.Step(`^each FinalitySignature is a valid signature of the block hash and era by the signer's public key$`, func() error {
+		for _, blockSignatures := range signatures {
+			for _, signature := range blockSignatures {
+				message := utils.GetFinalitySignatureBytes(signature.BlockHash, signature.EraID)
+
+				if err := signature.PublicKey.VerifySignature(message, signature.Signature); err != nil {
+					return fmt.Errorf("invalid finality signature from %s for block %s: %w",
+						signature.PublicKey.ToHex(), signature.BlockHash.String(), err)
+				}
+			}
+		}
+		return utils.Pass
+	})
+
+	ctx.Step(`^the finalised weight of each added block exceeds the chainspec finality threshold$`, func() error {
+		threshold, err := utils.GetFinalityThresholdFraction()
+		if err != nil {
+			return err
+		}
+
+		for _, blockHash := range addedBlocks {
+			block, err := sdk.GetBlockByHash(context.Background(), blockHash)
+			if err != nil {
+				return err
+			}
+
+			weights, err := utils.GetEraValidatorWeights(block.Block)
+			if err != nil {
+				return err
+			}
+
+			signedWeight := big.NewInt(0)
+			signers := make(map[string]bool)
+
+			for _, signature := range signatures[blockHash] {
+				signer := signature.PublicKey.ToHex()
+
+				if signature.EraID != uint64(block.Block.Header.EraID) {
+					return fmt.Errorf("signature era %d does not match the era %d of block %s", signature.EraID, block.Block.Header.EraID, blockHash)
+				}
+
+				weight, ok := weights[signer]
+				if !ok {
+					return fmt.Errorf("signer %s is not a validator of era %d", signer, block.Block.Header.EraID)
+				}
+
+				if !signers[signer] {
+					signers[signer] = true
+					signedWeight.Add(signedWeight, weight)
+				}
+			}
+
+			totalWeight := weights.TotalWeight()
+			if totalWeight.Sign() == 0 {
+				return errors.New("the era has no validator weight")
+			}
+
+			signedFraction := new(big.Rat).SetFrac(signedWeight, totalWeight)
+
+			if signedFraction.Cmp(threshold) <= 0 {
+				return fmt.Errorf("block %s signed weight fraction %s does not exceed the finality threshold %s",
+					blockHash, signedFraction.FloatString(4), threshold.FloatString(4))
+			}
+		}
+
+		return utils.Pass
+	})
+}
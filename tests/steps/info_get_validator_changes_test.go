@@ -3,11 +3,16 @@ package steps
 import (
 	"context"
 	"fmt"
+	"math/big"
+	"math/rand"
 	"testing"
 
 	"github.com/cucumber/godog"
 	"github.com/make-software/casper-go-sdk/casper"
 	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/types"
+	"github.com/make-software/casper-go-sdk/types/clvalue"
+	"github.com/make-software/casper-go-sdk/types/keypair"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/casper-sdks/terminus-go-tests/tests/utils"
@@ -21,6 +26,11 @@ func TestFeaturesInfoGetValidatorChanges(t *testing.T) {
 func InitializeInfoGetValidatorChanges(ctx *godog.ScenarioContext) {
 	var sdk casper.RPCClient
 	var validatorChanges rpc.InfoGetValidatorChangesResult
+	var validatorKey keypair.PrivateKey
+	var bidDeployResult rpc.PutDeployResult
+	var fundDeployResult rpc.PutDeployResult
+	var bidEra uint32
+	var expectedEra uint32
 
 	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
 		utils.ReadConfig()
@@ -41,7 +51,7 @@ func InitializeInfoGetValidatorChanges(ctx *godog.ScenarioContext) {
 
 		assert.NotNil(utils.CasperT, validatorChanges.Changes)
 
-		// TODO need to investigate how to ensure changes exist as we have none on nctl startup
+		// Changes only exist once a bid has been added or withdrawn, see the validator bid lifecycle steps
 
 		return utils.Pass
 	})
@@ -54,4 +64,163 @@ func InitializeInfoGetValidatorChanges(ctx *godog.ScenarioContext) {
 		}
 		return utils.Pass
 	})
+
+	ctx.Step(`^that a generated validator account is funded by the faucet with (\d+) motes$`, func(amount int64) error {
+		var err error
+		var faucetKey keypair.PrivateKey
+		var payment *big.Int
+		var deploy *types.Deploy
+		var fundDeploy rpc.InfoGetDeployResult
+
+		validatorKey, err = keypair.GeneratePrivateKey(keypair.ED25519)
+
+		if err == nil {
+			faucetKey, err = casper.NewED25519PrivateKeyFromPEMFile("../../assets/net-1/faucet/secret_key.pem")
+		}
+
+		if err == nil {
+			payment, err = utils.GetWasmlessTransferCost()
+		}
+
+		if err == nil {
+			deploy, err = utils.BuildTransferDeploy(faucetKey, clvalue.NewCLPublicKey(validatorKey.PublicKey()), big.NewInt(amount), rand.Uint64(), payment)
+		}
+
+		if err == nil {
+			fundDeployResult, err = sdk.PutDeploy(context.Background(), *deploy)
+		}
+
+		if err == nil {
+			fundDeploy, err = utils.WaitForDeploy(fundDeployResult.DeployHash.String(), 300)
+		}
+
+		if err == nil && fundDeploy.ExecutionResults[0].Result.Success == nil {
+			err = fmt.Errorf("funding deploy %s failed: %s",
+				fundDeployResult.DeployHash.String(),
+				fundDeploy.ExecutionResults[0].Result.Failure.ErrorMessage)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the generated validator account submits an add_bid of (\d+) motes with a delegation rate of (\d+)$`,
+		func(amount int64, delegationRate int) error {
+			args := &types.Args{}
+			args.AddArgument("public_key", clvalue.NewCLPublicKey(validatorKey.PublicKey())).
+				AddArgument("amount", *clvalue.NewCLUInt512(big.NewInt(amount))).
+				AddArgument("delegation_rate", *clvalue.NewCLUint8(uint8(delegationRate)))
+
+			var err error
+			bidDeployResult, err = utils.PutAuctionDeploy("add_bid", args, validatorKey)
+
+			return err
+		})
+
+	ctx.Step(`^the generated validator account submits a withdraw_bid of (\d+) motes$`, func(amount int64) error {
+		args := &types.Args{}
+		args.AddArgument("public_key", clvalue.NewCLPublicKey(validatorKey.PublicKey())).
+			AddArgument("amount", *clvalue.NewCLUInt512(big.NewInt(amount)))
+
+		var err error
+		bidDeployResult, err = utils.PutAuctionDeploy("withdraw_bid", args, validatorKey)
+
+		return err
+	})
+
+	ctx.Step(`^the bid deploy is successfully executed within (\d+) seconds$`, func(timeout int) error {
+		var block rpc.ChainGetBlockResult
+
+		deploy, err := utils.WaitForDeploy(bidDeployResult.DeployHash.String(), timeout)
+
+		if err == nil && deploy.ExecutionResults[0].Result.Success == nil {
+			err = fmt.Errorf("bid deploy %s failed: %s",
+				bidDeployResult.DeployHash.String(),
+				deploy.ExecutionResults[0].Result.Failure.ErrorMessage)
+		}
+
+		if err == nil {
+			block, err = sdk.GetBlockByHash(context.Background(), deploy.ExecutionResults[0].BlockHash.String())
+		}
+
+		if err == nil {
+			bidEra = block.Block.Header.EraID
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the auction info contains a bid of (\d+) motes for the generated validator account$`, func(amount int64) error {
+		auctionInfo, err := sdk.GetAuctionInfoLatest(context.Background())
+		if err != nil {
+			return err
+		}
+
+		bid := utils.FindAuctionBid(auctionInfo.AuctionState, validatorKey.PublicKey())
+		if bid == nil {
+			return fmt.Errorf("no bid found for %s", validatorKey.PublicKey().String())
+		}
+
+		return utils.ExpectEqual(utils.CasperT, "staked_amount", bid.Bid.StakedAmount.Value().String(), big.NewInt(amount).String())
+	})
+
+	ctx.Step(`^the auction info era validators include the generated validator account in a future era$`, func() error {
+		auctionInfo, err := sdk.GetAuctionInfoLatest(context.Background())
+		if err != nil {
+			return err
+		}
+
+		for _, eraValidators := range auctionInfo.AuctionState.EraValidators {
+			if eraValidators.EraID > bidEra && utils.IsEraValidator(eraValidators, validatorKey.PublicKey()) {
+				expectedEra = eraValidators.EraID
+				return utils.Pass
+			}
+		}
+
+		return fmt.Errorf("%s is not a validator in any era after %d", validatorKey.PublicKey().String(), bidEra)
+	})
+
+	// The era validators already calculated when the bid is withdrawn are not changed, the withdrawn validator is only
+	// excluded from the era calculated by the auction run at the end of the withdrawal era
+
+	ctx.Step(`^the auction info era validators exclude the generated validator account in a future era within (\d+) seconds$`, func(timeout int) error {
+		_, err := utils.WaitForEra(bidEra+1, timeout)
+		if err != nil {
+			return err
+		}
+
+		auctionInfo, err := sdk.GetAuctionInfoLatest(context.Background())
+		if err != nil {
+			return err
+		}
+
+		for _, eraValidators := range auctionInfo.AuctionState.EraValidators {
+			if eraValidators.EraID > bidEra && !utils.IsEraValidator(eraValidators, validatorKey.PublicKey()) {
+				expectedEra = eraValidators.EraID
+				return utils.Pass
+			}
+		}
+
+		return fmt.Errorf("%s is a validator in every era after %d", validatorKey.PublicKey().String(), bidEra)
+	})
+
+	ctx.Step(`^the validator change era is reached within (\d+) seconds$`, func(timeout int) error {
+		_, err := utils.WaitForEra(expectedEra, timeout)
+		return err
+	})
+
+	ctx.Step(`^the info_get_validator_changes_result reports the generated validator account as "([^"]*)"$`, func(state string) error {
+		for _, change := range validatorChanges.Changes {
+			if !change.PublicKey.Equals(validatorKey.PublicKey()) {
+				continue
+			}
+
+			for _, statusChange := range change.StatusChanges {
+				if statusChange.ValidatorState == rpc.ValidatorState(state) {
+					return utils.ExpectEqual(utils.CasperT, "era_id", statusChange.EraID, uint64(expectedEra))
+				}
+			}
+		}
+
+		return fmt.Errorf("no %s change found for %s", state, validatorKey.PublicKey().String())
+	})
 }
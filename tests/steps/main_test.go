@@ -0,0 +1,24 @@
+package steps
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/casper-sdks/terminus-go-tests/tests/utils"
+)
+
+// TestMain starts the deploy tracker, runs the feature tests, stops the tracker and logs the lifecycle latencies of the
+// deploys they put
+func TestMain(m *testing.M) {
+	utils.ReadConfig()
+
+	if err := utils.GetDeployTracker().Start(30); err != nil {
+		log.Printf("Deploy lifecycles are not tracked: %v", err)
+	}
+
+	code := m.Run()
+	utils.GetDeployTracker().Stop()
+	utils.LogDeploySummary()
+	os.Exit(code)
+}
@@ -3,7 +3,6 @@ package steps
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log"
 	"math/big"
@@ -18,6 +17,7 @@ import (
 	"github.com/make-software/casper-go-sdk/sse"
 	"github.com/make-software/casper-go-sdk/types"
 	"github.com/make-software/casper-go-sdk/types/clvalue"
+	"github.com/make-software/casper-go-sdk/types/key"
 	"github.com/make-software/casper-go-sdk/types/keypair"
 	"github.com/stretchr/testify/assert"
 
@@ -98,6 +98,34 @@ func InitializeQueryGlobalState(ctx *godog.ScenarioContext) {
 		return err
 	})
 
+	ctx.Step(`the query_global_state_result contains a valid merkle proof$`, func() error {
+		proofs, err := utils.ParseMerkleProof(globalState.MerkleProof)
+
+		if err == nil && len(proofs) == 0 {
+			err = fmt.Errorf("merkle proof is empty")
+		}
+
+		for _, proof := range proofs {
+			if err == nil {
+				err = utils.ExpectEqual(utils.CasperT,
+					"merkle proof state root hash",
+					proof.ComputeStateRootHash().String(),
+					globalState.BlockHeader.StateRootHash.String())
+			}
+		}
+
+		if err == nil {
+			var proofKey key.Key
+			proofKey, err = proofs[0].ParseKey()
+
+			if err == nil {
+				err = utils.ExpectEqual(utils.CasperT, "merkle proof key", proofKey.String(), "deploy-"+deployResult.DeployHash.String())
+			}
+		}
+
+		return err
+	})
+
 	ctx.Step(`the query_global_state_result contains a valid deploy info stored value$`, func() error {
 		if globalState.StoredValue.DeployInfo == nil {
 			return fmt.Errorf("missing value in global state")
@@ -148,7 +176,7 @@ func InitializeQueryGlobalState(ctx *godog.ScenarioContext) {
 			return fmt.Errorf("Should have error ")
 		}
 
-		expectedErr = utils.GetRpcError(errors.Unwrap(err))
+		expectedErr = utils.GetRpcError(err)
 
 		return utils.Pass
 	})
@@ -183,7 +211,7 @@ func InitializeQueryGlobalState(ctx *godog.ScenarioContext) {
 			return fmt.Errorf("Should have error ")
 		}
 
-		expectedErr = utils.GetRpcError(errors.Unwrap(err))
+		expectedErr = utils.GetRpcError(err)
 
 		return utils.Pass
 	})
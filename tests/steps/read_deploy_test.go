@@ -49,6 +49,10 @@ func InitializeReadDeploy(ctx *godog.ScenarioContext) {
 		return utils.Pass
 	})
 
+	ctx.Step(`^the deploy hash and body hash match the recomputed hashes$`, func() error {
+		return utils.VerifyDeployHashes(deploy)
+	})
+
 	ctx.Step(`^the deploy hash is "([^"]*)"$`, func(deployHash string) error {
 		return utils.ExpectEqual(utils.CasperT, "deployHash", deploy.Hash.String(), deployHash)
 	})
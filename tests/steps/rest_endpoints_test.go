@@ -0,0 +1,207 @@
+package steps
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/make-software/casper-go-sdk/casper"
+	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/casper-sdks/terminus-go-tests/tests/utils"
+)
+
+// The test features implementation for the rest_endpoints.feature
+func TestFeaturesRestEndpoints(t *testing.T) {
+	utils.TestFeatures(t, "rest_endpoints.feature", InitializeRestEndpoints)
+}
+
+func InitializeRestEndpoints(ctx *godog.ScenarioContext) {
+	var sdk casper.RPCClient
+	var restStatus rpc.InfoGetStatusResult
+	var rpcStatus rpc.InfoGetStatusResult
+	var restChainspec rpc.InfoGetChainspecResult
+	var restValidatorChanges rpc.InfoGetValidatorChangesResult
+
+	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+		utils.ReadConfig()
+		sdk = utils.GetRPCClient()
+		return ctx, nil
+	})
+
+	ctx.Step(`^that the REST status is requested$`, func() error {
+		var err error
+		restStatus, err = utils.GetRestStatus()
+		return err
+	})
+
+	ctx.Step(`^the info_get_status is requested via the sdk$`, func() error {
+		var err error
+		rpcStatus, err = sdk.GetStatus(context.Background())
+		return err
+	})
+
+	// The two requests are made at different times so only the fields that do not change while the node runs are
+	// compared, and the peers only by the ids of those connected to both
+
+	ctx.Step(`^the REST status is equal to the info_get_status_result$`, func() error {
+		err := utils.ExpectEqual(utils.CasperT, "api_version", restStatus.APIVersion, rpcStatus.APIVersion)
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "build_version", restStatus.BuildVersion, rpcStatus.BuildVersion)
+		}
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "chainspec_name", restStatus.ChainSpecName, rpcStatus.ChainSpecName)
+		}
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "our_public_signing_key", restStatus.OutPublicSigningKey, rpcStatus.OutPublicSigningKey)
+		}
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "starting_state_root_hash", restStatus.StartingStateRootHash, rpcStatus.StartingStateRootHash)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the REST status peers are connected to the node in the info_get_status_result$`, func() error {
+		rpcPeers := make(map[string]string)
+		for _, peer := range rpcStatus.Peers {
+			rpcPeers[peer.NodeID] = peer.Address
+		}
+
+		var common int
+
+		for _, peer := range restStatus.Peers {
+			address, ok := rpcPeers[peer.NodeID]
+			if !ok {
+				// The peer disconnected between the requests
+				continue
+			}
+
+			if err := utils.ExpectEqual(utils.CasperT, "peer address", peer.Address, address); err != nil {
+				return err
+			}
+
+			common++
+		}
+
+		if len(restStatus.Peers) > 0 && common == 0 {
+			return errors.New("none of the REST status peers are in the info_get_status_result")
+		}
+
+		return utils.Pass
+	})
+
+	// The chain progresses between the two requests so the last added blocks are compared by height
+
+	ctx.Step(`^the REST status last_added_block_info is at or below the info_get_status_result last_added_block_info$`, func() error {
+		if restStatus.LastAddedBlockInfo.Height > rpcStatus.LastAddedBlockInfo.Height {
+			return fmt.Errorf("REST status height %d is above info_get_status height %d",
+				restStatus.LastAddedBlockInfo.Height, rpcStatus.LastAddedBlockInfo.Height)
+		}
+		return utils.Pass
+	})
+
+	ctx.Step(`^the REST status last_added_block_info is the block at its height$`, func() error {
+		block, err := sdk.GetBlockByHeight(context.Background(), uint64(restStatus.LastAddedBlockInfo.Height))
+		if err != nil {
+			return err
+		}
+
+		err = utils.ExpectEqual(utils.CasperT, "hash", restStatus.LastAddedBlockInfo.Hash.String(), block.Block.Hash.String())
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "state_root_hash", restStatus.LastAddedBlockInfo.StateRootHash.String(), block.Block.Header.StateRootHash.String())
+		}
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "era_id", restStatus.LastAddedBlockInfo.EraID, block.Block.Header.EraID)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^that the REST chainspec is requested$`, func() error {
+		var err error
+		restChainspec, err = utils.GetRestChainspec()
+		return err
+	})
+
+	ctx.Step(`^the REST chainspec bytes are equal to the info_get_chainspec chainspec bytes$`, func() error {
+		chainspec, err := sdk.GetChainspec(context.Background())
+		if err != nil {
+			return err
+		}
+
+		err = utils.ExpectEqual(utils.CasperT, "chainspec_bytes", restChainspec.ChainspecBytes.ChainspecBytes, chainspec.ChainspecBytes.ChainspecBytes)
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "maybe_genesis_accounts_bytes",
+				restChainspec.ChainspecBytes.MaybeGenesisAccountsBytes, chainspec.ChainspecBytes.MaybeGenesisAccountsBytes)
+		}
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "maybe_global_state_bytes",
+				restChainspec.ChainspecBytes.MaybeGlobalStateBytes, chainspec.ChainspecBytes.MaybeGlobalStateBytes)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the REST chainspec is for the configured chain name$`, func() error {
+		chainspecBytes, err := hex.DecodeString(restChainspec.ChainspecBytes.ChainspecBytes)
+		if err != nil {
+			return err
+		}
+
+		if !strings.Contains(string(chainspecBytes), fmt.Sprintf("name = '%s'", utils.GetConfigChainName())) &&
+			!strings.Contains(string(chainspecBytes), fmt.Sprintf("name = \"%s\"", utils.GetConfigChainName())) {
+			return fmt.Errorf("the REST chainspec is not for the chain %s", utils.GetConfigChainName())
+		}
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^that the REST validator changes are requested$`, func() error {
+		var err error
+		restValidatorChanges, err = utils.GetRestValidatorChanges()
+
+		if err == nil && restValidatorChanges.Changes == nil {
+			err = errors.New("the REST validator changes have no changes list")
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the REST validator changes are equal to the info_get_validator_changes_result$`, func() error {
+		validatorChanges, err := sdk.GetValidatorChangesInfo(context.Background())
+		if err != nil {
+			return err
+		}
+
+		return utils.AssertExpectedAndActual(assert.ElementsMatch, restValidatorChanges.Changes, validatorChanges.Changes)
+	})
+
+	ctx.Step(`^the REST endpoint "([^"]*)" returns JSON with the field "([^"]*)"$`, func(path string, field string) error {
+		restJson, err := utils.GetRestJson(strings.TrimPrefix(path, "/"))
+		if err != nil {
+			return err
+		}
+
+		node, err := utils.GetNodeByJsonPath(restJson, field)
+
+		if err == nil && node == nil {
+			err = fmt.Errorf("the REST endpoint %s has no field %s", path, field)
+		}
+
+		return err
+	})
+}
@@ -0,0 +1,134 @@
+package steps
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/make-software/casper-go-sdk/casper"
+	"github.com/make-software/casper-go-sdk/types/keypair"
+
+	"github.com/casper-sdks/terminus-go-tests/tests/utils"
+)
+
+// The test features implementation for the rpc_errors.feature, each step keeps the error of its call in the scenario
+// context for the "the call fails with <name>" step to assert using the rpc-errors.yml catalogue
+func TestFeaturesRpcErrors(t *testing.T) {
+	utils.TestFeatures(t, "rpc_errors.feature", InitializeRpcErrors)
+}
+
+func InitializeRpcErrors(ctx *godog.ScenarioContext) {
+	var sdk casper.RPCClient
+	var unknownHash string
+	var stateRootHash string
+
+	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+		utils.ReadConfig()
+		sdk = utils.GetRPCClient()
+
+		hashBytes := make([]byte, 32)
+		_, err := rand.Read(hashBytes)
+		unknownHash = hex.EncodeToString(hashBytes)
+
+		return ctx, err
+	})
+
+	ctx.Step(`^that the latest state root hash is known$`, func() error {
+		result, err := sdk.GetStateRootHashLatest(context.Background())
+		stateRootHash = result.StateRootHash.String()
+		return err
+	})
+
+	ctx.Step(`^chain_get_block is invoked with an unknown block hash$`, func(ctx context.Context) (context.Context, error) {
+		_, err := sdk.GetBlockByHash(context.Background(), unknownHash)
+		return utils.WithRpcCall(ctx, "chain_get_block", err), utils.Pass
+	})
+
+	ctx.Step(`^chain_get_block is invoked with a block height (\d+) above the latest block$`, func(ctx context.Context, increment uint64) (context.Context, error) {
+		latest, err := sdk.GetBlockLatest(context.Background())
+		if err != nil {
+			return ctx, err
+		}
+
+		_, err = sdk.GetBlockByHeight(context.Background(), latest.Block.Header.Height+increment)
+		return utils.WithRpcCall(ctx, "chain_get_block", err), utils.Pass
+	})
+
+	ctx.Step(`^chain_get_block_transfers is invoked with an unknown block hash$`, func(ctx context.Context) (context.Context, error) {
+		_, err := sdk.GetBlockTransfersByHash(context.Background(), unknownHash)
+		return utils.WithRpcCall(ctx, "chain_get_block_transfers", err), utils.Pass
+	})
+
+	ctx.Step(`^chain_get_state_root_hash is invoked with an unknown block hash$`, func(ctx context.Context) (context.Context, error) {
+		_, err := sdk.GetStateRootHashByHash(context.Background(), unknownHash)
+		return utils.WithRpcCall(ctx, "chain_get_state_root_hash", err), utils.Pass
+	})
+
+	ctx.Step(`^state_get_auction_info is invoked with an unknown block hash$`, func(ctx context.Context) (context.Context, error) {
+		_, err := sdk.GetAuctionInfoByHash(context.Background(), unknownHash)
+		return utils.WithRpcCall(ctx, "state_get_auction_info", err), utils.Pass
+	})
+
+	ctx.Step(`^chain_get_era_info_by_switch_block is invoked with an unknown block hash$`, func(ctx context.Context) (context.Context, error) {
+		_, err := sdk.GetEraInfoByBlockHash(context.Background(), unknownHash)
+		return utils.WithRpcCall(ctx, "chain_get_era_info_by_switch_block", err), utils.Pass
+	})
+
+	ctx.Step(`^chain_get_era_summary is invoked with an unknown block hash$`, func(ctx context.Context) (context.Context, error) {
+		_, err := sdk.GetEraSummaryByHash(context.Background(), unknownHash)
+		return utils.WithRpcCall(ctx, "chain_get_era_summary", err), utils.Pass
+	})
+
+	ctx.Step(`^state_get_balance is invoked with an unknown purse uref$`, func(ctx context.Context) (context.Context, error) {
+		_, err := sdk.GetAccountBalance(context.Background(), &stateRootHash, "uref-"+unknownHash+"-007")
+		return utils.WithRpcCall(ctx, "state_get_balance", err), utils.Pass
+	})
+
+	ctx.Step(`^state_get_balance is invoked with a malformed purse uref$`, func(ctx context.Context) (context.Context, error) {
+		_, err := sdk.GetAccountBalance(context.Background(), &stateRootHash, "uref-"+unknownHash)
+		return utils.WithRpcCall(ctx, "state_get_balance", err), utils.Pass
+	})
+
+	ctx.Step(`^state_get_balance is invoked with an unknown state root hash$`, func(ctx context.Context) (context.Context, error) {
+		purseUref := "uref-" + unknownHash + "-007"
+		_, err := sdk.GetAccountBalance(context.Background(), &unknownHash, purseUref)
+		return utils.WithRpcCall(ctx, "state_get_balance", err), utils.Pass
+	})
+
+	ctx.Step(`^state_get_dictionary_item is invoked with an unknown dictionary uref$`, func(ctx context.Context) (context.Context, error) {
+		_, err := sdk.GetDictionaryItem(context.Background(), &stateRootHash, "uref-"+unknownHash+"-007", "unknown")
+		return utils.WithRpcCall(ctx, "state_get_dictionary_item", err), utils.Pass
+	})
+
+	ctx.Step(`^info_get_deploy is invoked with an unknown deploy hash$`, func(ctx context.Context) (context.Context, error) {
+		_, err := sdk.GetDeploy(context.Background(), unknownHash)
+		return utils.WithRpcCall(ctx, "info_get_deploy", err), utils.Pass
+	})
+
+	ctx.Step(`^query_global_state is invoked with a malformed key$`, func(ctx context.Context) (context.Context, error) {
+		_, err := sdk.QueryGlobalStateByStateHash(context.Background(), &stateRootHash, "not-a-key-"+unknownHash, nil)
+		return utils.WithRpcCall(ctx, "query_global_state", err), utils.Pass
+	})
+
+	ctx.Step(`^query_global_state is invoked with an unknown key$`, func(ctx context.Context) (context.Context, error) {
+		_, err := sdk.QueryGlobalStateByStateHash(context.Background(), &stateRootHash, "deploy-"+unknownHash, nil)
+		return utils.WithRpcCall(ctx, "query_global_state", err), utils.Pass
+	})
+
+	ctx.Step(`^state_get_account_info is invoked with an unknown public key$`, func(ctx context.Context) (context.Context, error) {
+		privateKey, err := keypair.GeneratePrivateKey(keypair.ED25519)
+		if err != nil {
+			return ctx, err
+		}
+
+		latest, err := sdk.GetBlockLatest(context.Background())
+		if err != nil {
+			return ctx, err
+		}
+
+		_, err = sdk.GetAccountInfoByBlochHash(context.Background(), latest.Block.Hash.String(), privateKey.PublicKey())
+		return utils.WithRpcCall(ctx, "state_get_account_info", err), utils.Pass
+	})
+}
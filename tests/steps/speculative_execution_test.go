@@ -34,6 +34,9 @@ func InitializeSpeculativeExecution(ctx *godog.ScenarioContext) {
 	var casperClient casper.RPCClient
 	var speculativeExecResult rpc.SpeculativeExecResult
 	var speculativeDeploy casper.Deploy
+	var estimatedDeploy *types.Deploy
+	var estimatedDeployResult rpc.PutDeployResult
+	var estimatedCost *big.Int
 
 	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
 		utils.ReadConfig()
@@ -296,6 +299,87 @@ func InitializeSpeculativeExecution(ctx *godog.ScenarioContext) {
 
 			return err
 		})
+
+	ctx.Step(`^that the "faucet" account transfers (\d+) to user-(\d+) account with a payment estimated using the speculative_exec RPC API$`,
+		func(transferAmount int64, userId int) error {
+			faucetKey, err := casper.NewED25519PrivateKeyFromPEMFile("../../assets/net-1/faucet/secret_key.pem")
+			if err != nil {
+				return err
+			}
+
+			var receiverKey keypair.PrivateKey
+			receiverKey, err = casper.NewED25519PrivateKeyFromPEMFile(utils.GetUserKeyAssetPath(1, userId, "secret_key.pem"))
+			if err != nil {
+				return err
+			}
+
+			header := types.DefaultHeader()
+			header.ChainName = utils.GetChainName()
+			header.Account = faucetKey.PublicKey()
+			header.Timestamp = types.Timestamp(time.Now())
+
+			args := &types.Args{}
+			args.AddArgument("amount", *clvalue.NewCLUInt512(big.NewInt(transferAmount)))
+			args.AddArgument("target", clvalue.NewCLPublicKey(receiverKey.PublicKey()))
+			args.AddArgument("id", clvalue.NewCLOption(*clvalue.NewCLUInt64(rand.Uint64())))
+
+			session := types.ExecutableDeployItem{
+				Transfer: &types.TransferDeployItem{
+					Args: *args,
+				},
+			}
+
+			// The cost is the one the payment was estimated from so the margin is asserted against a single execution
+			estimatedDeployResult, estimatedDeploy, estimatedCost, err = utils.PutDeployWithEstimatedCost(header, session, faucetKey)
+
+			return err
+		})
+
+	ctx.Step(`^the estimated payment amount is the speculative_exec cost plus the configured margin$`, func() error {
+		amount, err := estimatedDeploy.Payment.ModuleBytes.Args.Find("amount")
+		if err != nil {
+			return err
+		}
+
+		value, err := amount.Value()
+		if err != nil {
+			return err
+		}
+
+		expected := utils.ApplyPaymentMargin(estimatedCost, utils.GetPaymentMargin())
+
+		return utils.ExpectEqual(utils.CasperT, "payment amount", value.UI512.Value().String(), expected.String())
+	})
+
+	ctx.Step(`^the deploy with the estimated payment is successfully executed within (\d+) seconds$`, func(timeoutSeconds int) error {
+		deploy, err := utils.WaitForDeploy(estimatedDeployResult.DeployHash.String(), timeoutSeconds)
+
+		if err == nil && deploy.ExecutionResults[0].Result.Success == nil {
+			err = fmt.Errorf("deploy %s was not successful", estimatedDeployResult.DeployHash.String())
+		}
+
+		if err != nil {
+			return err
+		}
+
+		amount, err := estimatedDeploy.Payment.ModuleBytes.Args.Find("amount")
+		if err != nil {
+			return err
+		}
+
+		value, err := amount.Value()
+		if err != nil {
+			return err
+		}
+
+		cost := new(big.Int).SetUint64(deploy.ExecutionResults[0].Result.Success.Cost)
+
+		if cost.Cmp(value.UI512.Value()) > 0 {
+			return fmt.Errorf("cost %s exceeds estimated payment %s", cost, value.UI512.Value())
+		}
+
+		return utils.Pass
+	})
 }
 
 func createDeploy() (casper.Deploy, error) {
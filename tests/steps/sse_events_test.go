@@ -0,0 +1,475 @@
+package steps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/make-software/casper-go-sdk/casper"
+	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/sse"
+	"github.com/make-software/casper-go-sdk/types"
+
+	"github.com/casper-sdks/terminus-go-tests/tests/utils"
+)
+
+// The test features implementation for the sse_events.feature
+func TestFeaturesSseEvents(t *testing.T) {
+	utils.TestFeatures(t, "sse_events.feature", InitializeSseEvents)
+}
+
+func InitializeSseEvents(ctx *godog.ScenarioContext) {
+	var sdk casper.RPCClient
+	var apiVersions map[string]string
+	var eventDeploy *types.Deploy
+	var eventDeployResult rpc.PutDeployResult
+	var deployAccepted sse.DeployAcceptedEvent
+	var deployProcessed sse.DeployProcessedEvent
+	var deployExpired sse.DeployExpiredEvent
+	var step sse.StepEvent
+	var stepSwitchBlock types.Block
+	var faults []sse.FaultEvent
+	var shutdownEvent *sse.RawEvent
+	var stoppedNodeId int
+
+	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+		utils.ReadConfig()
+		sdk = utils.GetRPCClient()
+		apiVersions = make(map[string]string)
+		faults = make([]sse.FaultEvent, 0)
+		shutdownEvent = nil
+		return ctx, nil
+	})
+
+	// A node stopped by a scenario that failed before restarting it is restarted so the network is left running
+	ctx.After(func(ctx context.Context, _ *godog.Scenario, err error) (context.Context, error) {
+		if stoppedNodeId != 0 {
+			if startErr := utils.StartNode(stoppedNodeId); startErr != nil {
+				log.Printf("Could not restart node-%d: %v", stoppedNodeId, startErr)
+			}
+			stoppedNodeId = 0
+		}
+		return ctx, err
+	})
+
+	ctx.Step(`^the ApiVersion event is received from the "([^"]*)" stream within (\d+) seconds$`, func(stream string, timeout int) error {
+		event, err := utils.WaitForEvent(stream, sse.APIVersionEventType, timeout, func(sse.RawEvent) bool {
+			return true
+		})
+
+		var apiVersion sse.APIVersionEvent
+		if err == nil {
+			apiVersion, err = event.ParseAsAPIVersionEvent()
+		}
+
+		if err == nil {
+			apiVersions[stream] = apiVersion.APIVersion
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the ApiVersion event of the "([^"]*)" stream is a semantic version$`, func(stream string) error {
+		if !regexp.MustCompile(`^\d+\.\d+\.\d+$`).MatchString(apiVersions[stream]) {
+			return fmt.Errorf("invalid %s stream ApiVersion %s", stream, apiVersions[stream])
+		}
+		return utils.Pass
+	})
+
+	ctx.Step(`^every stream reports the same ApiVersion$`, func() error {
+		for stream, apiVersion := range apiVersions {
+			for otherStream, otherApiVersion := range apiVersions {
+				if apiVersion != otherApiVersion {
+					return fmt.Errorf("%s stream ApiVersion %s does not match %s stream ApiVersion %s",
+						stream, apiVersion, otherStream, otherApiVersion)
+				}
+			}
+		}
+		return utils.Pass
+	})
+
+	ctx.Step(`^that a transfer deploy is submitted for event tracking$`, func() error {
+		var err error
+		eventDeploy, err = utils.BuildStandardTransferDeploy(types.Args{})
+
+		if err == nil {
+			eventDeployResult, err = sdk.PutDeploy(context.Background(), *eventDeploy)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^that a transfer deploy with an unmet dependency and a ttl of (\d+) seconds is submitted for event tracking$`, func(ttl int) error {
+		var err error
+		eventDeploy, err = utils.BuildExpiringTransferDeploy(time.Duration(ttl)*time.Second, time.Now())
+
+		if err == nil {
+			eventDeployResult, err = sdk.PutDeploy(context.Background(), *eventDeploy)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^a DeployAccepted event is received for the deploy from the "deploys" stream within (\d+) seconds$`, func(timeout int) error {
+		event, err := utils.WaitForEvent("deploys", sse.DeployAcceptedEventType, timeout, func(event sse.RawEvent) bool {
+			accepted, err := event.ParseAsDeployAcceptedEvent()
+			return err == nil && accepted.DeployAccepted.Hash.String() == eventDeployResult.DeployHash.String()
+		})
+
+		if err == nil {
+			deployAccepted, err = event.ParseAsDeployAcceptedEvent()
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the DeployAccepted event contains the submitted deploy$`, func() error {
+		accepted := deployAccepted.DeployAccepted
+
+		err := utils.VerifyDeployHashes(accepted)
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "account", accepted.Header.Account.ToHex(), eventDeploy.Header.Account.ToHex())
+		}
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "body_hash", accepted.Header.BodyHash.String(), eventDeploy.Header.BodyHash.String())
+		}
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "approvals", len(accepted.Approvals), len(eventDeploy.Approvals))
+		}
+
+		for i := 0; err == nil && i < len(accepted.Approvals); i++ {
+			err = utils.ExpectEqual(utils.CasperT, "signature",
+				accepted.Approvals[i].Signature.String(),
+				eventDeploy.Approvals[i].Signature.String())
+		}
+
+		return err
+	})
+
+	ctx.Step(`^a DeployProcessed event is received for the deploy from the "main" stream within (\d+) seconds$`, func(timeout int) error {
+		event, err := utils.WaitForEvent("main", sse.DeployProcessedEventType, timeout, func(event sse.RawEvent) bool {
+			processed, err := event.ParseAsDeployProcessedEvent()
+			return err == nil && processed.DeployProcessed.DeployHash.String() == eventDeployResult.DeployHash.String()
+		})
+
+		if err == nil {
+			deployProcessed, err = event.ParseAsDeployProcessedEvent()
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the DeployProcessed event execution result matches the info_get_deploy execution result$`, func() error {
+		deploy, err := utils.WaitForDeploy(eventDeployResult.DeployHash.String(), 60)
+		if err != nil {
+			return err
+		}
+
+		processed := deployProcessed.DeployProcessed
+		expected := deploy.ExecutionResults[0]
+
+		err = utils.ExpectEqual(utils.CasperT, "block_hash", processed.BlockHash.String(), expected.BlockHash.String())
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "success", processed.ExecutionResult.Success != nil, expected.Result.Success != nil)
+		}
+
+		if err == nil && expected.Result.Success != nil {
+			err = compareExecutionResultData(*processed.ExecutionResult.Success, *expected.Result.Success)
+		}
+
+		if err == nil && expected.Result.Failure != nil {
+			err = compareExecutionResultData(*processed.ExecutionResult.Failure, *expected.Result.Failure)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^a DeployExpired event is received for the deploy from the "main" stream within (\d+) seconds$`, func(timeout int) error {
+		event, err := utils.WaitForEvent("main", sse.DeployExpiredEventType, timeout, func(event sse.RawEvent) bool {
+			expired, err := event.ParseAsDeployExpiredEvent()
+			return err == nil && expired.DeployExpired.DeployHash.String() == eventDeployResult.DeployHash.String()
+		})
+
+		if err == nil {
+			deployExpired, err = event.ParseAsDeployExpiredEvent()
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the DeployExpired event contains the deploy hash$`, func() error {
+		return utils.ExpectEqual(utils.CasperT, "deploy_hash", deployExpired.DeployExpired.DeployHash.String(), eventDeployResult.DeployHash.String())
+	})
+
+	ctx.Step(`^a Step event is received from the "main" stream within (\d+) seconds$`, func(timeout int) error {
+		var err error
+		stepSwitchBlock, err = utils.WaitForNextSwitchBlock(timeout)
+		if err != nil {
+			return err
+		}
+
+		// The Step event is sent when the switch block is executed so it is the one next to the switch block's BlockAdded
+		// event in the replayed events, either since the preceding block or the first after the switch block
+		var lock sync.Mutex
+		var pending *sse.RawEvent
+		var matched *sse.RawEvent
+		var switchBlockAdded bool
+
+		eventCtx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+		defer cancel()
+
+		sseClient := utils.GetSseStreamClient("main")
+
+		sseClient.RegisterHandler(sse.BlockAddedEventType, func(_ context.Context, event sse.RawEvent) error {
+			blockAdded, err := event.ParseAsBlockAddedEvent()
+			if err != nil {
+				return err
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			if blockAdded.BlockAdded.BlockHash != stepSwitchBlock.Hash.String() {
+				pending = nil
+				return utils.Pass
+			}
+
+			switchBlockAdded = true
+			if pending != nil {
+				matched = pending
+				cancel()
+			}
+			return utils.Pass
+		})
+
+		sseClient.RegisterHandler(sse.StepEventType, func(_ context.Context, event sse.RawEvent) error {
+			lock.Lock()
+			defer lock.Unlock()
+
+			if matched != nil {
+				return utils.Pass
+			}
+
+			if switchBlockAdded {
+				matched = &event
+				cancel()
+			} else {
+				pending = &event
+			}
+			return utils.Pass
+		})
+
+		err = sseClient.Start(eventCtx, 0)
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		if matched == nil {
+			return fmt.Errorf("no Step event was received for the switch block %s: %v", stepSwitchBlock.Hash.String(), err)
+		}
+
+		step, err = matched.ParseAsStepEvent()
+
+		return err
+	})
+
+	ctx.Step(`^the Step event is for the era ended by a switch block$`, func() error {
+		err := utils.ExpectEqual(utils.CasperT, "era_id", step.Step.EraID, uint64(stepSwitchBlock.Header.EraID))
+
+		if err == nil && stepSwitchBlock.Header.EraEnd == nil {
+			err = fmt.Errorf("block %s is not a switch block", stepSwitchBlock.Hash.String())
+		}
+
+		if err == nil && len(step.Step.ExecutionEffect.Transforms) == 0 {
+			err = errors.New("the Step event has no transforms")
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the "main" stream is listened to for Fault events for (\d+) blocks$`, func(blockCount int) error {
+		var lock sync.Mutex
+		var blocks int
+
+		eventCtx, cancel := context.WithTimeout(context.Background(), time.Duration(blockCount)*time.Minute)
+		defer cancel()
+
+		sseClient := utils.GetSseStreamClient("main")
+
+		sseClient.RegisterHandler(sse.BlockAddedEventType, func(_ context.Context, _ sse.RawEvent) error {
+			lock.Lock()
+			defer lock.Unlock()
+
+			if blocks++; blocks >= blockCount {
+				cancel()
+			}
+			return utils.Pass
+		})
+
+		// The SDK only logs a handler error so a Fault event that can not be parsed is recorded to fail the step
+		var parseErr error
+
+		sseClient.RegisterHandler(sse.FaultEventType, func(_ context.Context, event sse.RawEvent) error {
+			fault, err := event.ParseAsFaultEvent()
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			if err != nil {
+				if parseErr == nil {
+					parseErr = fmt.Errorf("the Fault event %d could not be parsed: %w", event.EventID, err)
+				}
+				return err
+			}
+
+			faults = append(faults, fault)
+			return utils.Pass
+		})
+
+		err := sseClient.Start(eventCtx, -1)
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		if parseErr != nil {
+			return parseErr
+		}
+
+		if blocks >= blockCount {
+			return utils.Pass
+		}
+
+		return fmt.Errorf("only %d of %d blocks were added: %w", blocks, blockCount, err)
+	})
+
+	ctx.Step(`^any Fault event is for a validator of its era$`, func() error {
+		for _, fault := range faults {
+			weights, err := getEraValidatorWeightsById(uint32(fault.Fault.EraID))
+			if err != nil {
+				return err
+			}
+
+			if _, ok := weights[fault.Fault.PublicKey.ToHex()]; !ok {
+				return fmt.Errorf("fault reported for %s which is not a validator of era %d", fault.Fault.PublicKey.ToHex(), fault.Fault.EraID)
+			}
+		}
+		return utils.Pass
+	})
+
+	ctx.Step(`^node-(\d+) is stopped while its "([^"]*)" stream is listened to for a Shutdown event within (\d+) seconds$`,
+		func(nodeId int, stream string, timeout int) error {
+			sseClient, err := utils.GetNodeSseStreamClient(nodeId, stream)
+			if err != nil {
+				return err
+			}
+
+			eventCtx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+			defer cancel()
+
+			sseClient.RegisterHandler(sse.ShutdownType, func(_ context.Context, event sse.RawEvent) error {
+				shutdownEvent = &event
+				cancel()
+				return utils.Pass
+			})
+
+			stopped := make(chan error, 1)
+
+			go func() {
+				// Allow the client to connect before stopping the node
+				time.Sleep(5 * time.Second)
+				stopErr := utils.StopNode(nodeId)
+				if stopErr != nil {
+					cancel()
+				}
+				stopped <- stopErr
+			}()
+
+			err = sseClient.Start(eventCtx, -1)
+
+			if stopErr := <-stopped; stopErr != nil {
+				return fmt.Errorf("could not stop node-%d: %w", nodeId, stopErr)
+			}
+
+			stoppedNodeId = nodeId
+
+			if shutdownEvent == nil {
+				return fmt.Errorf("no Shutdown event received from node-%d: %w", nodeId, err)
+			}
+
+			return utils.Pass
+		})
+
+	ctx.Step(`^the Shutdown event is parsed as a Shutdown event type$`, func() error {
+		return utils.ExpectEqual(utils.CasperT, "event type", sse.AllEventsNames[shutdownEvent.EventType], sse.AllEventsNames[sse.ShutdownType])
+	})
+
+	ctx.Step(`^node-(\d+) is restarted$`, func(nodeId int) error {
+		err := utils.StartNode(nodeId)
+
+		if err == nil {
+			stoppedNodeId = 0
+		}
+
+		return err
+	})
+}
+
+// getEraValidatorWeightsById obtains the validator weights of an era, era 0 has no previous switch block so its weights
+// are read from the genesis block's auction info
+func getEraValidatorWeightsById(eraID uint32) (utils.ValidatorWeights, error) {
+	if eraID == 0 {
+		genesis, err := utils.GetRPCClient().GetBlockByHeight(context.Background(), 0)
+		if err != nil {
+			return nil, err
+		}
+		return utils.GetEraValidatorWeights(genesis.Block)
+	}
+
+	switchBlock, err := utils.GetSwitchBlock(eraID - 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.GetNextEraValidatorWeights(switchBlock)
+}
+
+// compareExecutionResultData compares an event's execution result with the one from the info_get_deploy RPC API
+func compareExecutionResultData(actual types.ExecutionResultStatusData, expected types.ExecutionResultStatusData) error {
+	err := utils.ExpectEqual(utils.CasperT, "cost", actual.Cost, expected.Cost)
+
+	if err == nil {
+		err = utils.ExpectEqual(utils.CasperT, "error_message", actual.ErrorMessage, expected.ErrorMessage)
+	}
+
+	if err == nil {
+		err = utils.ExpectEqual(utils.CasperT, "transfers", len(actual.Transfers), len(expected.Transfers))
+	}
+
+	if err == nil {
+		err = utils.ExpectEqual(utils.CasperT, "transforms", len(actual.Effect.Transforms), len(expected.Effect.Transforms))
+	}
+
+	for i := 0; err == nil && i < len(actual.Effect.Transforms); i++ {
+		err = utils.ExpectEqual(utils.CasperT, "transform key",
+			actual.Effect.Transforms[i].Key.String(),
+			expected.Effect.Transforms[i].Key.String())
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "transform",
+				string(actual.Effect.Transforms[i].Transform),
+				string(expected.Effect.Transforms[i].Transform))
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,329 @@
+package steps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/make-software/casper-go-sdk/casper"
+	"github.com/make-software/casper-go-sdk/sse"
+
+	"github.com/casper-sdks/terminus-go-tests/tests/utils"
+)
+
+// The test features implementation for the sse_resume.feature
+func TestFeaturesSseResume(t *testing.T) {
+	utils.TestFeatures(t, "sse_resume.feature", InitializeSseResume)
+}
+
+func InitializeSseResume(ctx *godog.ScenarioContext) {
+	var sdk casper.RPCClient
+	var proxy *utils.EventStreamProxy
+	var receivedEvents []sse.RawEvent
+	var resumedEvents []sse.RawEvent
+	var directEvents []sse.RawEvent
+	var lastEventID uint64
+	var reconnects int
+	var clientErr error
+
+	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+		utils.ReadConfig()
+		sdk = utils.GetRPCClient()
+		proxy = nil
+		receivedEvents = make([]sse.RawEvent, 0)
+		resumedEvents = make([]sse.RawEvent, 0)
+		directEvents = make([]sse.RawEvent, 0)
+		lastEventID = 0
+		reconnects = 0
+		clientErr = nil
+		return ctx, nil
+	})
+
+	ctx.After(func(ctx context.Context, _ *godog.Scenario, err error) (context.Context, error) {
+		if proxy != nil {
+			_ = proxy.Close()
+		}
+		return ctx, err
+	})
+
+	ctx.Step(`^that the "main" stream is listened to until (\d+) BlockAdded events are received within (\d+) seconds$`,
+		func(blockCount int, timeout int) error {
+			events, err := listenForBlockEvents(utils.GetSseStreamClient("main"), -1, blockCount, timeout)
+
+			receivedEvents = append(receivedEvents, events...)
+			if len(events) > 0 {
+				lastEventID = events[len(events)-1].EventID
+			}
+
+			return err
+		})
+
+	ctx.Step(`^the listener is stopped while (\d+) blocks are added within (\d+) seconds$`, func(blockCount uint64, timeout int) error {
+		latest, err := sdk.GetBlockLatest(context.Background())
+
+		if err == nil {
+			_, err = utils.WaitForBlockHeight(latest.Block.Header.Height+blockCount, timeout)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the "main" stream is resumed after the last received event id until (\d+) BlockAdded events are received within (\d+) seconds$`,
+		func(blockCount int, timeout int) error {
+			events, err := listenForBlockEvents(utils.GetSseStreamClient("main"), int(lastEventID)+1, blockCount, timeout)
+
+			resumedEvents = events
+			receivedEvents = append(receivedEvents, events...)
+
+			return err
+		})
+
+	ctx.Step(`^the resumed events start after the last received event id$`, func() error {
+		if len(resumedEvents) == 0 {
+			return errors.New("no events were resumed")
+		}
+
+		if resumedEvents[0].EventID <= lastEventID {
+			return fmt.Errorf("the first resumed event id %d is not after the last received event id %d", resumedEvents[0].EventID, lastEventID)
+		}
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^no event is received twice$`, func() error {
+		var previousID uint64
+
+		for i, event := range receivedEvents {
+			if i > 0 && event.EventID <= previousID {
+				return fmt.Errorf("event id %d was received after event id %d", event.EventID, previousID)
+			}
+			previousID = event.EventID
+		}
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^the BlockAdded events are for consecutive block heights$`, func() error {
+		var previousHeight uint64
+		var blocks int
+
+		for _, event := range receivedEvents {
+			if event.EventType != sse.BlockAddedEventType {
+				continue
+			}
+
+			blockAdded, err := event.ParseAsBlockAddedEvent()
+			if err != nil {
+				return err
+			}
+
+			height := blockAdded.BlockAdded.Block.Header.Height
+
+			if blocks > 0 && height != previousHeight+1 {
+				return fmt.Errorf("block height %d was received after block height %d", height, previousHeight)
+			}
+
+			previousHeight = height
+			blocks++
+		}
+
+		return utils.Pass
+	})
+
+	ctx.Step(`^that an event stream proxy is started$`, func() error {
+		var err error
+		proxy, err = utils.NewEventStreamProxy()
+		return err
+	})
+
+	ctx.Step(`^the "main" stream is listened to through the proxy and the connection is dropped after (\d+) BlockAdded events?$`,
+		func(blockCount int) error {
+			var blocks int
+
+			eventCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+
+			sseClient := proxy.GetStreamClient("main")
+
+			sseClient.RegisterHandler(sse.BlockAddedEventType, func(_ context.Context, _ sse.RawEvent) error {
+				if blocks++; blocks == blockCount {
+					proxy.DropConnections()
+				}
+				return utils.Pass
+			})
+
+			clientErr = sseClient.Start(eventCtx, -1)
+
+			if blocks < blockCount {
+				return fmt.Errorf("only %d of %d blocks were added before the connection was dropped", blocks, blockCount)
+			}
+
+			return utils.Pass
+		})
+
+	ctx.Step(`^the SDK client stops with a connection error$`, func() error {
+		if clientErr == nil || strings.Contains(clientErr.Error(), "context") {
+			return fmt.Errorf("expected a connection error from the SDK client but got %v", clientErr)
+		}
+		return utils.Pass
+	})
+
+	ctx.Step(`^the "main" stream is listened to through the proxy with resume, dropping the connection every (\d+) BlockAdded events until (\d+) BlockAdded events are received within (\d+) seconds$`,
+		func(dropEvery int, blockCount int, timeout int) error {
+			var lock sync.Mutex
+			var proxyBlocks int
+
+			eventCtx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+			defer cancel()
+
+			// The same stream is listened to directly to obtain the events the proxied listener should receive
+			directCtx, directCancel := context.WithCancel(eventCtx)
+			directClient := utils.GetSseStreamClient("main")
+
+			for _, eventType := range utils.MainStreamEventTypes {
+				directClient.RegisterHandler(eventType, func(_ context.Context, event sse.RawEvent) error {
+					lock.Lock()
+					defer lock.Unlock()
+
+					if event.EventType != sse.APIVersionEventType {
+						directEvents = append(directEvents, event)
+					}
+					return utils.Pass
+				})
+			}
+
+			var waitGroup sync.WaitGroup
+			waitGroup.Add(1)
+
+			go func() {
+				defer waitGroup.Done()
+				_ = directClient.Start(directCtx, -1)
+			}()
+
+			var err error
+			reconnects, err = utils.ListenWithResume(eventCtx, func() *sse.Client {
+				return proxy.GetStreamClient("main")
+			}, utils.MainStreamEventTypes, -1, func(event sse.RawEvent) {
+				if event.EventType == sse.APIVersionEventType {
+					return
+				}
+
+				lock.Lock()
+				defer lock.Unlock()
+
+				receivedEvents = append(receivedEvents, event)
+
+				if event.EventType != sse.BlockAddedEventType {
+					return
+				}
+
+				if proxyBlocks++; proxyBlocks == blockCount {
+					cancel()
+				} else if proxyBlocks%dropEvery == 0 {
+					proxy.DropConnections()
+				}
+			})
+
+			// Allow the direct listener to receive the events of the last block before stopping it
+			time.Sleep(time.Second)
+			directCancel()
+			waitGroup.Wait()
+
+			if err == nil && proxyBlocks < blockCount {
+				err = fmt.Errorf("only %d of %d blocks were received through the proxy", proxyBlocks, blockCount)
+			}
+
+			return err
+		})
+
+	ctx.Step(`^the listener reconnected at least (\d+) times$`, func(count int) error {
+		if reconnects < count {
+			return fmt.Errorf("the listener reconnected %d times, expected at least %d", reconnects, count)
+		}
+		return utils.Pass
+	})
+
+	ctx.Step(`^the events received through the proxy match the events received directly$`, func() error {
+		if len(receivedEvents) == 0 || len(directEvents) == 0 {
+			return errors.New("no events were received")
+		}
+
+		// Only the range of event ids received by both listeners can be compared
+		firstID := max(receivedEvents[0].EventID, directEvents[0].EventID)
+		lastID := min(receivedEvents[len(receivedEvents)-1].EventID, directEvents[len(directEvents)-1].EventID)
+
+		proxiedIDs := getEventIDsInRange(receivedEvents, firstID, lastID)
+		directIDs := getEventIDsInRange(directEvents, firstID, lastID)
+
+		err := utils.ExpectEqual(utils.CasperT, "event count", len(proxiedIDs), len(directIDs))
+
+		for i := 0; err == nil && i < len(directIDs); i++ {
+			err = utils.ExpectEqual(utils.CasperT, "event id", proxiedIDs[i], directIDs[i])
+		}
+
+		return err
+	})
+}
+
+// listenForBlockEvents collects the events of the 'main' stream until a number of BlockAdded events are received
+func listenForBlockEvents(sseClient *sse.Client, startFrom int, blockCount int, timeout int) ([]sse.RawEvent, error) {
+	var lock sync.Mutex
+	var blocks int
+	events := make([]sse.RawEvent, 0)
+
+	eventCtx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	for _, eventType := range utils.MainStreamEventTypes {
+		sseClient.RegisterHandler(eventType, func(_ context.Context, event sse.RawEvent) error {
+			if event.EventType == sse.APIVersionEventType {
+				return utils.Pass
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			if blocks < blockCount {
+				events = append(events, event)
+			}
+
+			if event.EventType == sse.BlockAddedEventType {
+				if blocks++; blocks == blockCount {
+					cancel()
+				}
+			}
+			return utils.Pass
+		})
+	}
+
+	err := sseClient.Start(eventCtx, startFrom)
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if blocks >= blockCount {
+		return events, nil
+	}
+
+	if err == nil || strings.Contains(err.Error(), "context") {
+		err = fmt.Errorf("only %d of %d BlockAdded events were received", blocks, blockCount)
+	}
+
+	return events, err
+}
+
+// getEventIDsInRange obtains the ids of the events within an inclusive range of ids
+func getEventIDsInRange(events []sse.RawEvent, firstID uint64, lastID uint64) []uint64 {
+	ids := make([]uint64, 0)
+	for _, event := range events {
+		if event.EventID >= firstID && event.EventID <= lastID {
+			ids = append(ids, event.EventID)
+		}
+	}
+	return ids
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/cucumber/godog"
 	"github.com/make-software/casper-go-sdk/casper"
 	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/types/key"
 	"github.com/make-software/casper-go-sdk/types/keypair"
 
 	"github.com/casper-sdks/terminus-go-tests/tests/utils"
@@ -94,15 +95,30 @@ func InitializeStateGetAccountInfoFeature(ctx *godog.ScenarioContext) {
 	})
 
 	ctx.Step(`^the state_get_account_info_result contain a valid merkle proof$`, func() error {
-		// Merkel Proof missing
+		// The SDK does not return the merkle proof so the node's proof is verified and its key compared with the SDK's
+		var proofs []utils.TrieMerkleProof
+		var proofKey key.Key
+
 		merkleProof, _ := utils.GetByJsonPath(accountInfoJson, "/result/merkle_proof")
 		if merkleProof == "" {
 			return errors.New("merkle_proof missing")
 		}
 
-		// Merkel Proof missing not failing as missing in other SDKs
-		// return utils.ExpectEqual(utils.CasperT, "associated_keys", accountInfo.MerkleProof, merkleProof)
-		return utils.Pass
+		err := utils.VerifyMerkleProof(merkleProof, latest.Block.Header.StateRootHash.String())
+
+		if err == nil {
+			proofs, err = utils.DecodeMerkleProof(merkleProof)
+		}
+
+		if err == nil {
+			proofKey, err = proofs[0].ParseKey()
+		}
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "merkle proof key", proofKey.String(), accountInfo.Account.AccountHash.ToPrefixedString())
+		}
+
+		return err
 	})
 
 	ctx.Step(`^the state_get_account_info_result contain a valid associated keys$`, func() error {
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/big"
 	"strconv"
 	"testing"
 
@@ -12,6 +11,7 @@ import (
 	"github.com/cucumber/godog"
 	"github.com/make-software/casper-go-sdk/casper"
 	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/types"
 
 	"github.com/casper-sdks/terminus-go-tests/tests/utils"
 )
@@ -25,7 +25,7 @@ func InitializeStateAuctionInfoFeature(ctx *godog.ScenarioContext) {
 	var sdk casper.RPCClient
 	var auctionInfo rpc.StateGetAuctionInfoResult
 	var jsonAuctionInfo string
-	var rpcErr rpc.RpcError
+	var rpcErr utils.RpcErrorResult
 
 	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
 		utils.ReadConfig()
@@ -51,7 +51,7 @@ func InitializeStateAuctionInfoFeature(ctx *godog.ScenarioContext) {
 		latest, err := sdk.GetBlockLatest(context.Background())
 
 		if err == nil {
-			jsonAuctionInfo, err = utils.GetAuctionInfoByHash(latest.Block.Header.ParentHash.String())
+			jsonAuctionInfo, err = utils.GetAuctionInfoByHeight(latest.Block.Header.Height)
 		}
 
 		if err == nil {
@@ -65,7 +65,7 @@ func InitializeStateAuctionInfoFeature(ctx *godog.ScenarioContext) {
 		_, err := sdk.GetAuctionInfoByHash(context.Background(), "9608b4b7029a18ae35373eab879f523850a1b1fd43a3e6da774826a343af4ad2")
 
 		if err != nil {
-			rpcErr = utils.GetRpcError(err)
+			rpcErr = utils.InspectRpcError(err)
 			return utils.Pass
 		} else {
 			return errors.New("should have given rpc error")
@@ -117,179 +117,236 @@ func InitializeStateAuctionInfoFeature(ctx *godog.ScenarioContext) {
 	})
 
 	ctx.Step(`^the state_get_auction_info_result action_state has valid bids$`, func() error {
-		var publicKey *jsonquery.Node
-		var bondingPurse *jsonquery.Node
-		var delegationRate *jsonquery.Node
-		var inactive *jsonquery.Node
-		var stakedAmount *jsonquery.Node
-		val := big.Int{}
-
 		bidsNode, err := utils.GetNodeByJsonPath(jsonAuctionInfo, "/result/auction_state/bids")
-
-		if err == nil {
-			err = utils.ExpectEqual(utils.CasperT,
-				"bids length",
-				len(auctionInfo.AuctionState.Bids),
-				len(bidsNode.ChildNodes()))
+		if err != nil {
+			return err
 		}
 
-		if err == nil {
-			publicKey, err = jsonquery.Query(bidsNode.FirstChild, "/public_key")
+		if bidsNode == nil {
+			return errors.New("missing bids")
 		}
 
-		if err == nil {
-			err = utils.ExpectEqual(utils.CasperT,
-				"public_key",
-				auctionInfo.AuctionState.Bids[0].PublicKey.String(),
-				publicKey.Value())
-		}
+		err = utils.ExpectEqual(utils.CasperT,
+			"bids length",
+			len(auctionInfo.AuctionState.Bids),
+			len(bidsNode.ChildNodes()))
 
-		if err == nil {
-			bondingPurse, err = jsonquery.Query(bidsNode.FirstChild, "/bid/bonding_purse")
+		for i, bidNode := range bidsNode.ChildNodes() {
+			if err == nil {
+				err = compareAuctionBid(auctionInfo.AuctionState.Bids[i], bidNode)
+			}
 		}
 
-		if err == nil {
-			err = utils.ExpectEqual(
-				utils.CasperT,
-				"public_key",
-				auctionInfo.AuctionState.Bids[0].Bid.BondingPurse.String(),
-				bondingPurse.Value())
-		}
+		return err
+	})
 
-		if err == nil {
-			delegationRate, err = jsonquery.Query(bidsNode.FirstChild, "/bid/delegation_rate")
-		}
+	ctx.Step(`^the state_get_auction_info_result action_state bids have valid vesting schedules$`, func() error {
+		var err error
 
-		if err == nil {
-			err = utils.ExpectEqual(
-				utils.CasperT,
-				"public_key",
-				auctionInfo.AuctionState.Bids[0].Bid.DelegationRate,
-				float32(delegationRate.Value().(float64)))
+		for _, bid := range auctionInfo.AuctionState.Bids {
+			if err == nil {
+				err = compareVestingSchedule(sdk, auctionInfo.AuctionState.StateRootHash, bid)
+			}
 		}
 
-		if err == nil {
-			inactive, err = jsonquery.Query(bidsNode.FirstChild, "/bid/inactive")
-		}
+		return err
+	})
 
-		if err == nil {
-			err = utils.ExpectEqual(
-				utils.CasperT,
-				"public_key",
-				auctionInfo.AuctionState.Bids[0].Bid.Inactive,
-				inactive.Value())
+	ctx.Step(`^the state_get_auction_info_result action_state has valid era validators$`, func() error {
+		validatorsNode, err := utils.GetNodeByJsonPath(jsonAuctionInfo, "/result/auction_state/era_validators")
+		if err != nil {
+			return err
 		}
 
-		if err == nil {
-			stakedAmount, err = jsonquery.Query(bidsNode.FirstChild, "/bid/staked_amount")
-			val.SetString(fmt.Sprintf("%v", stakedAmount.Value()), 10)
+		if validatorsNode == nil {
+			return errors.New("missing era validators")
 		}
 
-		if err == nil {
-			err = utils.ExpectEqual(
-				utils.CasperT,
-				"public_key",
-				auctionInfo.AuctionState.Bids[0].Bid.StakedAmount,
-				val.Uint64())
-		}
+		err = utils.ExpectEqual(
+			utils.CasperT,
+			"era validators length",
+			len(auctionInfo.AuctionState.EraValidators),
+			len(validatorsNode.ChildNodes()))
 
-		if err == nil {
-			var delegators *jsonquery.Node
-			var delegatee *jsonquery.Node
-			delegators, _ = jsonquery.Query(bidsNode.FirstChild, "/bid/delegators")
-			delegatee, _ = jsonquery.Query(delegators.FirstChild, "/delegatee")
+		for i, eraNode := range validatorsNode.ChildNodes() {
+			if err != nil {
+				break
+			}
+
+			eraValidators := auctionInfo.AuctionState.EraValidators[i]
+			eraId := jsonquery.FindOne(eraNode, "/era_id")
 
 			err = utils.ExpectEqual(
 				utils.CasperT,
-				"public_key",
-				auctionInfo.AuctionState.Bids[0].Bid.Delegators[0].Delegatee.String(),
-				delegatee.Value())
+				"eraId",
+				eraValidators.EraID,
+				uint32(eraId.Value().(float64)))
+
+			weightNodes := jsonquery.FindOne(eraNode, "/validator_weights").ChildNodes()
+
+			if err == nil {
+				err = utils.ExpectEqual(utils.CasperT, "validator weights length", len(eraValidators.ValidatorWeights), len(weightNodes))
+			}
+
+			for j, weightNode := range weightNodes {
+				if err == nil {
+					err = utils.ExpectEqual(
+						utils.CasperT,
+						"public_key",
+						eraValidators.ValidatorWeights[j].Validator.String(),
+						jsonquery.FindOne(weightNode, "/public_key").Value())
+				}
+
+				if err == nil {
+					err = utils.ExpectEqual(
+						utils.CasperT,
+						"weight",
+						eraValidators.ValidatorWeights[j].Weight.Value().String(),
+						fmt.Sprintf("%v", jsonquery.FindOne(weightNode, "/weight").Value()))
+				}
+			}
 		}
 
-		if err == nil {
-			var delegators *jsonquery.Node
-			var publicKey *jsonquery.Node
-			delegators, _ = jsonquery.Query(bidsNode.FirstChild, "/bid/delegators")
-			publicKey, _ = jsonquery.Query(delegators.FirstChild, "/public_key")
+		return err
+	})
 
-			err = utils.ExpectEqual(
-				utils.CasperT,
-				"public_key",
-				auctionInfo.AuctionState.Bids[0].Bid.Delegators[0].PublicKey.String(),
-				publicKey.Value())
-		}
+	ctx.Step(`^an error code of -(\d+) is returned$`, func(errorCode int) error {
+		return rpcErr.ExpectCode(-1 * errorCode)
+	})
 
-		if err == nil {
-			var delegators *jsonquery.Node
-			var stakedAmount *jsonquery.Node
-			delegators, _ = jsonquery.Query(bidsNode.FirstChild, "/bid/delegators")
-			stakedAmount, _ = jsonquery.Query(delegators.FirstChild, "/staked_amount")
-			val := big.Int{}
-			val.SetString(fmt.Sprintf("%v", stakedAmount.Value()), 10)
+	ctx.Step(`^an error message of "([^"]*)" is returned$`, func(errorMessage string) error {
+		return rpcErr.ExpectMessage(errorMessage)
+	})
+}
 
-			err = utils.ExpectEqual(
-				utils.CasperT,
-				"public_key",
-				auctionInfo.AuctionState.Bids[0].Bid.Delegators[0].StakedAmount,
-				val.Uint64())
+// compareAuctionBid compares every field of an SDK auction bid and its delegators with the node's JSON bid
+func compareAuctionBid(bid types.ValidatorBid, bidNode *jsonquery.Node) error {
+	err := utils.ExpectEqual(utils.CasperT, "public_key", bid.PublicKey.String(), jsonquery.FindOne(bidNode, "/public_key").Value())
+
+	if err == nil {
+		err = utils.ExpectEqual(utils.CasperT,
+			"bonding_purse",
+			bid.Bid.BondingPurse.String(),
+			jsonquery.FindOne(bidNode, "/bid/bonding_purse").Value())
+	}
+
+	if err == nil {
+		err = utils.ExpectEqual(utils.CasperT,
+			"staked_amount",
+			bid.Bid.StakedAmount.Value().String(),
+			fmt.Sprintf("%v", jsonquery.FindOne(bidNode, "/bid/staked_amount").Value()))
+	}
+
+	if err == nil {
+		err = utils.ExpectEqual(utils.CasperT,
+			"delegation_rate",
+			bid.Bid.DelegationRate,
+			float32(jsonquery.FindOne(bidNode, "/bid/delegation_rate").Value().(float64)))
+	}
+
+	if err == nil {
+		err = utils.ExpectEqual(utils.CasperT, "inactive", bid.Bid.Inactive, jsonquery.FindOne(bidNode, "/bid/inactive").Value())
+	}
+
+	delegatorNodes := jsonquery.FindOne(bidNode, "/bid/delegators").ChildNodes()
+
+	if err == nil {
+		err = utils.ExpectEqual(utils.CasperT, "delegators length", len(bid.Bid.Delegators), len(delegatorNodes))
+	}
+
+	for i, delegatorNode := range delegatorNodes {
+		if err != nil {
+			break
 		}
 
-		return err
-	})
+		delegator := bid.Bid.Delegators[i]
 
-	ctx.Step(`^the state_get_auction_info_result action_state has valid era validators$`, func() error {
-		validatorsNode, err := utils.GetNodeByJsonPath(jsonAuctionInfo, "/result/auction_state/era_validators")
+		err = utils.ExpectEqual(utils.CasperT,
+			"delegator public_key",
+			delegator.PublicKey.String(),
+			jsonquery.FindOne(delegatorNode, "/public_key").Value())
 
 		if err == nil {
-			err = utils.ExpectEqual(
-				utils.CasperT,
-				"bids length",
-				len(auctionInfo.AuctionState.EraValidators),
-				len(validatorsNode.ChildNodes()))
+			err = utils.ExpectEqual(utils.CasperT,
+				"delegatee",
+				delegator.Delegatee.String(),
+				jsonquery.FindOne(delegatorNode, "/delegatee").Value())
 		}
 
 		if err == nil {
-			eraId := jsonquery.FindOne(validatorsNode.FirstChild, "/era_id")
+			err = utils.ExpectEqual(utils.CasperT,
+				"delegator bonding_purse",
+				delegator.BondingPurse.String(),
+				jsonquery.FindOne(delegatorNode, "/bonding_purse").Value())
+		}
 
-			err = utils.ExpectEqual(
-				utils.CasperT,
-				"eraId",
-				auctionInfo.AuctionState.EraValidators[0].EraID,
-				uint32(eraId.Value().(float64)))
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT,
+				"delegator staked_amount",
+				delegator.StakedAmount.Value().String(),
+				fmt.Sprintf("%v", jsonquery.FindOne(delegatorNode, "/staked_amount").Value()))
 		}
+	}
 
-		validatorWeights := jsonquery.FindOne(validatorsNode.FirstChild, "/validator_weights")
+	return err
+}
 
-		if err == nil {
-			publicKey := jsonquery.FindOne(validatorWeights.FirstChild, "/public_key")
+// compareVestingSchedule compares a validator's vesting schedule from the SDK's bid in global state with the node's,
+// the auction info does not contain vesting schedules so the bid is read from global state
+func compareVestingSchedule(sdk casper.RPCClient, stateRootHash string, bid types.ValidatorBid) error {
+	bidKey := "bid-" + bid.PublicKey.AccountHash().ToHex()
 
-			err = utils.ExpectEqual(
-				utils.CasperT,
-				"eraId",
-				auctionInfo.AuctionState.EraValidators[0].ValidatorWeights[0].Validator.String(),
-				publicKey.Value())
-		}
+	stateResult, err := sdk.QueryGlobalStateByStateHash(context.Background(), &stateRootHash, bidKey, nil)
+	if err != nil {
+		return err
+	}
 
-		if err == nil {
-			weight := jsonquery.FindOne(validatorWeights.FirstChild, "/weight")
-			val := big.Int{}
-			val.SetString(fmt.Sprintf("%v", weight.Value()), 10)
+	if stateResult.StoredValue.Bid == nil {
+		return fmt.Errorf("bid %s not found in global state", bidKey)
+	}
 
-			err = utils.ExpectEqual(
-				utils.CasperT,
-				"eraId",
-				auctionInfo.AuctionState.EraValidators[0].ValidatorWeights[0].Weight.Value().String(),
-				val.String())
-		}
+	stateJson, err := utils.QueryGlobalState(stateRootHash, bidKey)
+	if err != nil {
+		return err
+	}
 
+	vestingNode, err := utils.GetNodeByJsonPath(stateJson, "/result/stored_value/Bid/vesting_schedule")
+	if err != nil {
 		return err
-	})
+	}
 
-	ctx.Step(`^an error code of -(\d+) is returned$`, func(errorCode int) error {
-		return utils.ExpectEqual(utils.CasperT, "error code", rpcErr.Code, -1*errorCode)
-	})
+	vestingSchedule := stateResult.StoredValue.Bid.VestingSchedule
 
-	ctx.Step(`^an error message of "([^"]*)" is returned$`, func(errorMessage string) error {
-		return utils.ExpectEqual(utils.CasperT, "error code", rpcErr.Message, errorMessage)
-	})
+	if vestingNode == nil || vestingNode.Value() == nil {
+		return utils.ExpectEqual(utils.CasperT, "vesting_schedule", vestingSchedule == nil, true)
+	}
+
+	if vestingSchedule == nil {
+		return fmt.Errorf("missing vesting schedule for %s", bid.PublicKey.String())
+	}
+
+	err = utils.ExpectEqual(utils.CasperT,
+		"initial_release_timestamp_millis",
+		vestingSchedule.InitialReleaseTimestampMillis,
+		uint64(jsonquery.FindOne(vestingNode, "/initial_release_timestamp_millis").Value().(float64)))
+
+	var lockedAmountNodes []*jsonquery.Node
+	if lockedAmounts := jsonquery.FindOne(vestingNode, "/locked_amounts"); lockedAmounts != nil {
+		lockedAmountNodes = lockedAmounts.ChildNodes()
+	}
+
+	if err == nil {
+		err = utils.ExpectEqual(utils.CasperT, "locked_amounts length", len(vestingSchedule.LockedAmounts), len(lockedAmountNodes))
+	}
+
+	for i, lockedAmountNode := range lockedAmountNodes {
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT,
+				"locked_amount",
+				vestingSchedule.LockedAmounts[i].Value().String(),
+				fmt.Sprintf("%v", lockedAmountNode.Value()))
+		}
+	}
+
+	return err
 }
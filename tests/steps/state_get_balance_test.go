@@ -8,6 +8,7 @@ import (
 	"github.com/cucumber/godog"
 	"github.com/make-software/casper-go-sdk/casper"
 	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/types/clvalue"
 	"github.com/make-software/casper-go-sdk/types/keypair"
 
 	"github.com/casper-sdks/terminus-go-tests/tests/utils"
@@ -24,6 +25,7 @@ func InitializeStateGetBalance(ctx *godog.ScenarioContext) {
 	var accountKey keypair.PrivateKey
 	var latestBlock rpc.ChainGetBlockResult
 	var stateRootHash rpc.ChainGetStateRootHashResult
+	var mainPurse string
 
 	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
 		utils.ReadConfig()
@@ -52,7 +54,8 @@ func InitializeStateGetBalance(ctx *godog.ScenarioContext) {
 
 		if err == nil {
 			hashStr := stateRootHash.StateRootHash.String()
-			balance, err = sdk.GetAccountBalance(context.Background(), &hashStr, accountInfo.Account.MainPurse.String())
+			mainPurse = accountInfo.Account.MainPurse.String()
+			balance, err = sdk.GetAccountBalance(context.Background(), &hashStr, mainPurse)
 		}
 
 		return err
@@ -85,7 +88,34 @@ func InitializeStateGetBalance(ctx *godog.ScenarioContext) {
 	})
 
 	ctx.Step(`the state_get_balance_result contains a valid merkle proof`, func() error {
-		// Doest not contain a merkle proof	 - Ignoring as other SDKs do not fetch this
-		return utils.Pass
+		// The SDK does not return the merkle proof so the node's proof is verified and its value compared with the SDK's
+		var proofs []utils.TrieMerkleProof
+		var proofValue clvalue.CLValue
+		srh := stateRootHash.StateRootHash.String()
+
+		balanceJson, err := utils.GetStateBalanceJson(srh, mainPurse)
+
+		if err == nil {
+			var merkleProof string
+			merkleProof, err = utils.GetByJsonPath(balanceJson, "/result/merkle_proof")
+
+			if err == nil {
+				err = utils.VerifyMerkleProof(merkleProof, srh)
+			}
+
+			if err == nil {
+				proofs, err = utils.DecodeMerkleProof(merkleProof)
+			}
+		}
+
+		if err == nil {
+			proofValue, err = proofs[0].ParseCLValue()
+		}
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "merkle proof balance", proofValue.UI512.Value().String(), balance.BalanceValue.Value().String())
+		}
+
+		return err
 	})
 }
@@ -2,11 +2,21 @@ package steps
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cucumber/godog"
 	"github.com/make-software/casper-go-sdk/casper"
 	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/types"
+	"github.com/make-software/casper-go-sdk/types/clvalue"
+	"github.com/make-software/casper-go-sdk/types/keypair"
 
 	"github.com/casper-sdks/terminus-go-tests/tests/utils"
 )
@@ -18,56 +28,316 @@ func TestFeaturesStateGetDictionaryItem(t *testing.T) {
 
 func InitializeStateGetDictionaryItem(ctx *godog.ScenarioContext) {
 	var sdk casper.RPCClient
-	var mainPurse string
+	var faucetKey keypair.PrivateKey
 	var dictionaryItem rpc.StateGetDictionaryResult
-	var faucetAccountHash string
+	var dictionaryKeys []string
+	var stateRootHash string
+	var contractHash string
+	var seedUref string
 
 	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
 		utils.ReadConfig()
 		sdk = utils.GetRPCClient()
+		dictionaryKeys = make([]string, 0)
 		return ctx, nil
 	})
 
-	ctx.Step(`^that the state_get_dictionary_item RCP method is invoked$`, func() error {
-		var latestBlock rpc.ChainGetBlockResult
-		var accountInfo rpc.StateGetAccountInfo
-		var stateRootHash rpc.ChainGetStateRootHashResult
+	ctx.Step(`^that the ERC-20 contract "([^"]*)" is installed by the faucet with a total supply of (\d+)$`,
+		func(wasmFileName string, totalSupply int64) error {
+			var err error
+			var wasmBytes []byte
+			var deployResult rpc.PutDeployResult
+			var deploy rpc.InfoGetDeployResult
 
-		faucetKey, err := casper.NewED25519PrivateKeyFromPEMFile("../../assets/net-1/faucet/secret_key.pem")
+			faucetKey, err = casper.NewED25519PrivateKeyFromPEMFile("../../assets/net-1/faucet/secret_key.pem")
 
-		if err == nil {
-			faucetAccountHash = faucetKey.PublicKey().AccountHash().String()
-			stateRootHash, err = sdk.GetStateRootHashLatest(context.Background())
+			if err == nil {
+				wasmBytes, err = os.ReadFile(fmt.Sprintf("../contracts/%s", wasmFileName))
+			}
+
+			if err != nil {
+				return err
+			}
+
+			header := types.DefaultHeader()
+			header.ChainName = utils.GetChainName()
+			header.Account = faucetKey.PublicKey()
+			header.Timestamp = types.Timestamp(time.Now())
+
+			args := &types.Args{}
+			args.AddArgument("token_decimals", *clvalue.NewCLUint8(11)).
+				AddArgument("token_name", *clvalue.NewCLString("Acme Token")).
+				AddArgument("token_symbol", *clvalue.NewCLString("ACME")).
+				AddArgument("token_total_supply", *clvalue.NewCLUInt256(big.NewInt(totalSupply)))
+
+			session := types.ExecutableDeployItem{
+				ModuleBytes: &types.ModuleBytes{
+					ModuleBytes: hex.EncodeToString(wasmBytes),
+					Args:        args,
+				},
+			}
+
+			deployResult, _, err = utils.PutDeployWithEstimatedPayment(header, session, faucetKey)
+
+			if err == nil {
+				deploy, err = utils.WaitForDeploy(deployResult.DeployHash.String(), 300)
+			}
+
+			if err == nil && deploy.ExecutionResults[0].Result.Success == nil {
+				err = fmt.Errorf("deploy %s was not successful", deployResult.DeployHash.String())
+			}
+
+			return err
+		})
+
+	ctx.Step(`^the contract hash is obtained from the faucet account named key "([^"]*)"$`, func(name string) error {
+		latest, err := sdk.GetStateRootHashLatest(context.Background())
+		if err != nil {
+			return err
+		}
+
+		stateRootHash = latest.StateRootHash.String()
+		accountHash := faucetKey.PublicKey().AccountHash().ToPrefixedString()
+
+		stateResult, err := sdk.QueryGlobalStateByStateHash(context.Background(), &stateRootHash, accountHash, nil)
+
+		if err == nil && stateResult.StoredValue.Account == nil {
+			err = errors.New("faucet account not found in global state")
 		}
 
 		if err == nil {
-			latestBlock, err = sdk.GetBlockLatest(context.Background())
+			for _, namedKey := range stateResult.StoredValue.Account.NamedKeys {
+				if strings.EqualFold(namedKey.Name, name) {
+					contractHash = namedKey.Key.String()
+				}
+			}
+
+			if contractHash == "" {
+				err = fmt.Errorf("named key %s not found", name)
+			}
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the "([^"]*)" dictionary seed URef is obtained from the contract named keys$`, func(dictionaryName string) error {
+		stateResult, err := sdk.QueryGlobalStateByStateHash(context.Background(), &stateRootHash, contractHash, nil)
+
+		if err == nil && stateResult.StoredValue.Contract == nil {
+			err = fmt.Errorf("contract %s not found in global state", contractHash)
 		}
 
 		if err == nil {
-			accountInfo, err = sdk.GetAccountInfoByBlochHash(context.Background(), latestBlock.Block.Hash.String(), faucetKey.PublicKey())
-			mainPurse = accountInfo.Account.MainPurse.String()
+			dictionaryKey, err := stateResult.StoredValue.Contract.NamedKeys.Find(dictionaryName)
+			if err != nil {
+				return err
+			}
+
+			if dictionaryKey.URef == nil {
+				return fmt.Errorf("dictionary %s is not a URef", dictionaryName)
+			}
+
+			seedUref = dictionaryKey.URef.String()
 		}
+
+		return err
+	})
+
+	ctx.Step(`^the state_get_dictionary_item RCP method is invoked by the seed URef and the faucet balance item key$`, func() error {
+		var err error
+		itemKey := utils.GetErc20BalanceItemKey(faucetKey.PublicKey().AccountHash())
+
+		dictionaryItem, err = sdk.GetDictionaryItemByIdentifier(context.Background(), &stateRootHash, rpc.ParamDictionaryIdentifier{
+			URef: &rpc.ParamDictionaryIdentifierURef{
+				SeedUref:          seedUref,
+				DictionaryItemKey: itemKey,
+			},
+		})
+
 		if err == nil {
-			// TODO find an example of how to use URef to obtain dictionary item
+			dictionaryKeys = append(dictionaryKeys, dictionaryItem.DictionaryKey)
+		}
+
+		return err
+	})
+
+	// The ERC-20 contract keeps its dictionary seed URefs to itself so the faucet creates a dictionary in its own named keys
+	// holding its balance item
+
+	ctx.Step(`^that the faucet stores the "([^"]*)" dictionary in its named keys with a faucet balance item of (\d+)$`,
+		func(dictionaryName string, balance int64) error {
+			var deployResult rpc.PutDeployResult
+			var deploy rpc.InfoGetDeployResult
+
+			itemKey := utils.GetErc20BalanceItemKey(faucetKey.PublicKey().AccountHash())
+
+			wasmBytes, err := utils.BuildDictionarySessionWasm(dictionaryName, itemKey, *clvalue.NewCLUInt256(big.NewInt(balance)))
+			if err != nil {
+				return err
+			}
+
+			header := types.DefaultHeader()
+			header.ChainName = utils.GetChainName()
+			header.Account = faucetKey.PublicKey()
+			header.Timestamp = types.Timestamp(time.Now())
+
+			session := types.ExecutableDeployItem{
+				ModuleBytes: &types.ModuleBytes{
+					ModuleBytes: hex.EncodeToString(wasmBytes),
+					Args:        &types.Args{},
+				},
+			}
+
+			deployResult, _, err = utils.PutDeployWithEstimatedPayment(header, session, faucetKey)
+
+			if err == nil {
+				deploy, err = utils.WaitForDeploy(deployResult.DeployHash.String(), 300)
+			}
 
-			srh := stateRootHash.StateRootHash.String()
-			dictionaryItem, err = sdk.GetDictionaryItem(context.Background(), &srh, mainPurse, "wtfDoIPutHereCantSeeAnyWorkingExampleInAnyCodeBase")
+			if err == nil && deploy.ExecutionResults[0].Result.Success == nil {
+				err = fmt.Errorf("deploy %s was not successful", deployResult.DeployHash.String())
+			}
+
+			if err != nil {
+				return err
+			}
+
+			latest, err := sdk.GetStateRootHashLatest(context.Background())
+			if err != nil {
+				return err
+			}
+
+			stateRootHash = latest.StateRootHash.String()
+
+			return utils.Pass
+		})
+
+	ctx.Step(`^the state_get_dictionary_item RCP method is invoked by the account named key "([^"]*)" dictionary and the faucet balance item key$`,
+		func(dictionaryName string) error {
+			var err error
+			itemKey := utils.GetErc20BalanceItemKey(faucetKey.PublicKey().AccountHash())
+
+			dictionaryItem, err = sdk.GetDictionaryItemByIdentifier(context.Background(), &stateRootHash, rpc.ParamDictionaryIdentifier{
+				AccountNamedKey: &rpc.AccountNamedKey{
+					Key:               faucetKey.PublicKey().AccountHash().ToPrefixedString(),
+					DictionaryName:    dictionaryName,
+					DictionaryItemKey: itemKey,
+				},
+			})
+
+			return err
+		})
+
+	ctx.Step(`^the state_get_dictionary_item_result is the address of the faucet balance item in the account named key "([^"]*)" dictionary$`,
+		func(dictionaryName string) error {
+			accountHash := faucetKey.PublicKey().AccountHash().ToPrefixedString()
+
+			stateResult, err := sdk.QueryGlobalStateByStateHash(context.Background(), &stateRootHash, accountHash, nil)
+
+			if err == nil && stateResult.StoredValue.Account == nil {
+				err = errors.New("faucet account not found in global state")
+			}
+
+			if err != nil {
+				return err
+			}
+
+			dictionaryKey, err := stateResult.StoredValue.Account.NamedKeys.Find(dictionaryName)
+			if err != nil {
+				return err
+			}
+
+			if dictionaryKey.URef == nil {
+				return fmt.Errorf("dictionary %s is not a URef", dictionaryName)
+			}
+
+			itemKey := utils.GetErc20BalanceItemKey(faucetKey.PublicKey().AccountHash())
+
+			return utils.ExpectEqual(utils.CasperT, "dictionary_key", dictionaryItem.DictionaryKey,
+				utils.ComputeDictionaryAddress(*dictionaryKey.URef, itemKey))
+		})
+
+	ctx.Step(`^the state_get_dictionary_item RCP method is invoked by the contract named key "([^"]*)" dictionary and the faucet balance item key$`,
+		func(dictionaryName string) error {
+			var err error
+			itemKey := utils.GetErc20BalanceItemKey(faucetKey.PublicKey().AccountHash())
+
+			dictionaryItem, err = sdk.GetDictionaryItemByIdentifier(context.Background(), &stateRootHash, rpc.ParamDictionaryIdentifier{
+				ContractNamedKey: &rpc.ParamDictionaryIdentifierContractNamedKey{
+					Key:               contractHash,
+					DictionaryName:    dictionaryName,
+					DictionaryItemKey: itemKey,
+				},
+			})
+
+			if err == nil {
+				dictionaryKeys = append(dictionaryKeys, dictionaryItem.DictionaryKey)
+			}
+
+			return err
+		})
+
+	ctx.Step(`^the state_get_dictionary_item RCP method is invoked by the dictionary address$`, func() error {
+		if len(dictionaryKeys) == 0 {
+			return errors.New("no dictionary address has been obtained")
+		}
+
+		var err error
+		dictionaryAddress := dictionaryKeys[0]
+
+		dictionaryItem, err = sdk.GetDictionaryItemByIdentifier(context.Background(), &stateRootHash, rpc.ParamDictionaryIdentifier{
+			Dictionary: &dictionaryAddress,
+		})
+
+		if err == nil {
+			dictionaryKeys = append(dictionaryKeys, dictionaryItem.DictionaryKey)
 		}
 
 		return err
 	})
 
 	ctx.Step(`^a valid state_get_dictionary_item_result is returned$`, func() error {
-		var err error
-		accountHash := dictionaryItem.StoredValue.Account.AccountHash.String()
+		if !strings.HasPrefix(dictionaryItem.DictionaryKey, "dictionary-") {
+			return fmt.Errorf("invalid dictionary key %s", dictionaryItem.DictionaryKey)
+		}
 
-		err = utils.ExpectEqual(utils.CasperT, "accountHash", accountHash, faucetAccountHash)
+		if dictionaryItem.StoredValue.CLValue == nil {
+			return errors.New("missing CLValue stored value")
+		}
+
+		if len(dictionaryItem.MerkleProof) == 0 {
+			return errors.New("missing merkle proof")
+		}
+
+		status, err := sdk.GetStatus(context.Background())
+		if err != nil {
+			return err
+		}
+
+		return utils.ExpectEqual(utils.CasperT, "apiVersion", dictionaryItem.ApiVersion, status.APIVersion)
+	})
+
+	ctx.Step(`^the state_get_dictionary_item_result contains a "([^"]*)" value of (\d+)$`, func(typeName string, value int64) error {
+		clValue, err := dictionaryItem.StoredValue.CLValue.Value()
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "type", clValue.Type.Name(), typeName)
+		}
 
 		if err == nil {
-			err = utils.ExpectEqual(utils.CasperT, "mainPurse", dictionaryItem.StoredValue.Account.MainPurse.String(), mainPurse)
+			err = utils.ExpectEqual(utils.CasperT, "value", clValue.String(), big.NewInt(value).String())
 		}
 
 		return err
 	})
+
+	ctx.Step(`^every identifier resolves to the same dictionary address$`, func() error {
+		for _, dictionaryKey := range dictionaryKeys {
+			err := utils.ExpectEqual(utils.CasperT, "dictionary_key", dictionaryKey, dictionaryKeys[0])
+			if err != nil {
+				return err
+			}
+		}
+		return utils.Pass
+	})
 }
@@ -0,0 +1,194 @@
+package steps
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/make-software/casper-go-sdk/casper"
+	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/types"
+	"github.com/make-software/casper-go-sdk/types/key"
+	"github.com/make-software/casper-go-sdk/types/keypair"
+
+	"github.com/casper-sdks/terminus-go-tests/tests/utils"
+)
+
+// The test features implementation for the transfer_targets.feature
+func TestFeaturesTransferTargets(t *testing.T) {
+	utils.TestFeatures(t, "transfer_targets.feature", InitializeTransferTargets)
+}
+
+func InitializeTransferTargets(ctx *godog.ScenarioContext) {
+	var sdk casper.RPCClient
+	var receiverKey keypair.PrivateKey
+	var targetDeployResult rpc.PutDeployResult
+	var targetDeployInfo rpc.InfoGetDeployResult
+	var blockTransfer types.Transfer
+
+	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+		utils.ReadConfig()
+		sdk = utils.GetRPCClient()
+		return ctx, nil
+	})
+
+	ctx.Step(`^that user-(\d+) transfers (\d+) motes to user-(\d+) by (public key|account hash|key|purse uref) with the transfer id (\d+)$`,
+		func(senderId int, amount int64, receiverId int, targetKind string, transferId uint64) error {
+			senderKey, err := casper.NewED25519PrivateKeyFromPEMFile(utils.GetUserKeyAssetPath(1, senderId, "secret_key.pem"))
+			if err != nil {
+				return err
+			}
+
+			receiverKey, err = casper.NewED25519PrivateKeyFromPEMFile(utils.GetUserKeyAssetPath(1, receiverId, "secret_key.pem"))
+			if err != nil {
+				return err
+			}
+
+			target, err := utils.GetTransferTarget(targetKind, receiverKey.PublicKey())
+			if err != nil {
+				return err
+			}
+
+			payment, err := utils.GetWasmlessTransferCost()
+			if err != nil {
+				return err
+			}
+
+			deploy, err := utils.BuildTransferDeploy(senderKey, target, big.NewInt(amount), transferId, payment)
+
+			if err == nil {
+				targetDeployResult, err = sdk.PutDeploy(context.Background(), *deploy)
+			}
+
+			return err
+		})
+
+	ctx.Step(`^the targeted transfer is executed successfully within (\d+) seconds$`, func(timeout int) error {
+		var err error
+		targetDeployInfo, err = utils.WaitForDeploy(targetDeployResult.DeployHash.String(), timeout)
+
+		if err == nil && targetDeployInfo.ExecutionResults[0].Result.Success == nil {
+			err = fmt.Errorf("transfer deploy %s failed: %s", targetDeployResult.DeployHash.String(),
+				targetDeployInfo.ExecutionResults[0].Result.Failure.ErrorMessage)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^chain_get_block_transfers of the execution block contains the targeted transfer$`, func() error {
+		transfers, err := sdk.GetBlockTransfersByHash(context.Background(), targetDeployInfo.ExecutionResults[0].BlockHash.String())
+		if err != nil {
+			return err
+		}
+
+		for _, transfer := range transfers.Transfers {
+			if transfer.DeployHash.String() == targetDeployResult.DeployHash.String() {
+				blockTransfer = transfer
+				return utils.Pass
+			}
+		}
+
+		return fmt.Errorf("no transfer for deploy %s in block %s", targetDeployResult.DeployHash.String(), transfers.BlockHash)
+	})
+
+	ctx.Step(`^the block transfer id is (\d+)$`, func(transferId uint64) error {
+		return utils.ExpectEqual(utils.CasperT, "id", blockTransfer.ID, transferId)
+	})
+
+	ctx.Step(`^the block transfer amount is (\d+) motes$`, func(amount int64) error {
+		return utils.ExpectEqual(utils.CasperT, "amount", blockTransfer.Amount.Value().String(), big.NewInt(amount).String())
+	})
+
+	ctx.Step(`^the block transfer is to the receiver account$`, func() error {
+		return expectTransferTo(blockTransfer.To, receiverKey.PublicKey())
+	})
+
+	ctx.Step(`^the block transfer has no to account$`, func() error {
+		if blockTransfer.To != nil {
+			return fmt.Errorf("expected no to account but got %s", blockTransfer.To.ToPrefixedString())
+		}
+		return utils.Pass
+	})
+
+	ctx.Step(`^the block transfer target is the receiver main purse$`, func() error {
+		mainPurse, err := getMainPurse(sdk, receiverKey.PublicKey(), targetDeployInfo.ExecutionResults[0].BlockHash.String())
+
+		if err == nil {
+			err = expectSamePurse(blockTransfer.Target, mainPurse)
+		}
+
+		return err
+	})
+
+	ctx.Step(`^the WriteTransfer transform of the targeted transfer matches the block transfer$`, func() error {
+		var writeTransfer *types.WriteTransfer
+
+		for _, transform := range targetDeployInfo.ExecutionResults[0].Result.Success.Effect.Transforms {
+			if transform.Transform.IsWriteTransfer() {
+				var err error
+				if writeTransfer, err = transform.Transform.ParseAsWriteTransfer(); err != nil {
+					return err
+				}
+				break
+			}
+		}
+
+		if writeTransfer == nil {
+			return errors.New("no WriteTransfer transform found")
+		}
+
+		if writeTransfer.ID == nil {
+			return errors.New("the WriteTransfer transform has no id")
+		}
+
+		err := utils.ExpectEqual(utils.CasperT, "id", *writeTransfer.ID, blockTransfer.ID)
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "deploy_hash", writeTransfer.DeployHash.String(), blockTransfer.DeployHash.String())
+		}
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "amount", writeTransfer.Amount.Value().String(), blockTransfer.Amount.Value().String())
+		}
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "target", writeTransfer.Target.String(), blockTransfer.Target.String())
+		}
+
+		if err == nil {
+			err = utils.ExpectEqual(utils.CasperT, "to", writeTransfer.To == nil, blockTransfer.To == nil)
+		}
+
+		if err == nil && writeTransfer.To != nil {
+			err = utils.ExpectEqual(utils.CasperT, "to", writeTransfer.To.ToPrefixedString(), blockTransfer.To.ToPrefixedString())
+		}
+
+		return err
+	})
+}
+
+// expectTransferTo checks a transfer's to account is the account of the public key
+func expectTransferTo(to *key.AccountHash, publicKey keypair.PublicKey) error {
+	if to == nil {
+		return fmt.Errorf("expected the to account %s but got none", publicKey.AccountHash().ToPrefixedString())
+	}
+	return utils.ExpectEqual(utils.CasperT, "to", to.ToPrefixedString(), publicKey.AccountHash().ToPrefixedString())
+}
+
+// expectSamePurse checks two URefs address the same purse, their access rights may differ
+func expectSamePurse(actual key.URef, expected key.URef) error {
+	if !bytes.Equal(actual.Bytes()[:key.ByteHashLen], expected.Bytes()[:key.ByteHashLen]) {
+		return fmt.Errorf("purse %s is not %s", actual.String(), expected.String())
+	}
+	return utils.Pass
+}
+
+// getMainPurse obtains the main purse of an account at a block
+func getMainPurse(sdk casper.RPCClient, publicKey keypair.PublicKey, blockHash string) (key.URef, error) {
+	accountInfo, err := sdk.GetAccountInfoByBlochHash(context.Background(), blockHash, publicKey)
+	return accountInfo.Account.MainPurse, err
+}
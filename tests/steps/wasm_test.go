@@ -74,8 +74,6 @@ func InitializeWasmFeature(ctx *godog.ScenarioContext) {
 			AddArgument("token_symbol", *clvalue.NewCLString("ACME")).
 			AddArgument("token_total_supply", *clvalue.NewCLUInt256(big.NewInt(500000000000)))
 
-		payment := types.StandardPayment(big.NewInt(200000000000))
-
 		session := types.ExecutableDeployItem{
 			ModuleBytes: &types.ModuleBytes{
 				ModuleBytes: hex.EncodeToString(wasmBytes),
@@ -83,15 +81,7 @@ func InitializeWasmFeature(ctx *godog.ScenarioContext) {
 			},
 		}
 
-		deploy, err := types.MakeDeploy(header, payment, session)
-
-		if err == nil {
-			err = deploy.SignDeploy(faucetKey)
-		}
-
-		if err == nil {
-			wasmDeployResult, err = sdk.PutDeploy(context.Background(), *deploy)
-		}
+		wasmDeployResult, _, err = utils.PutDeployWithEstimatedPayment(header, session, faucetKey)
 
 		return err
 	})
@@ -183,8 +173,6 @@ func InitializeWasmFeature(ctx *godog.ScenarioContext) {
 			hash, err := casper.NewContractHash(strings.Split(contractHash, "-")[1])
 
 			if err == nil {
-				var deploy *types.Deploy
-
 				header := types.DefaultHeader()
 				header.ChainName = utils.GetChainName()
 				header.Account = faucetKey.PublicKey()
@@ -206,17 +194,7 @@ func InitializeWasmFeature(ctx *godog.ScenarioContext) {
 					},
 				}
 
-				payment := types.StandardPayment(big.NewInt(2500000000))
-
-				deploy, err = types.MakeDeploy(header, payment, session)
-
-				if err == nil {
-					err = deploy.SignDeploy(faucetKey)
-				}
-
-				if err == nil {
-					wasmDeployResult, err = sdk.PutDeploy(context.Background(), *deploy)
-				}
+				wasmDeployResult, _, err = utils.PutDeployWithEstimatedPayment(header, session, faucetKey)
 			}
 
 			return err
@@ -237,8 +215,6 @@ func InitializeWasmFeature(ctx *godog.ScenarioContext) {
 			recipient, err := keypair.GeneratePrivateKey(keypair.ED25519)
 
 			if err == nil {
-				var deploy *types.Deploy
-
 				header := types.DefaultHeader()
 				header.ChainName = utils.GetChainName()
 				header.Account = faucetKey.PublicKey()
@@ -260,17 +236,7 @@ func InitializeWasmFeature(ctx *godog.ScenarioContext) {
 					},
 				}
 
-				payment := types.StandardPayment(big.NewInt(2500000000))
-
-				deploy, err = types.MakeDeploy(header, payment, session)
-
-				if err == nil {
-					err = deploy.SignDeploy(faucetKey)
-				}
-
-				if err == nil {
-					wasmDeployResult, err = sdk.PutDeploy(context.Background(), *deploy)
-				}
+				wasmDeployResult, _, err = utils.PutDeployWithEstimatedPayment(header, session, faucetKey)
 			}
 
 			return err
@@ -281,7 +247,6 @@ func InitializeWasmFeature(ctx *godog.ScenarioContext) {
 			recipient, err := keypair.GeneratePrivateKey(keypair.ED25519)
 
 			if err == nil {
-				var deploy *types.Deploy
 				var version json.Number = "1"
 				var hash key.ContractHash
 				hash, err = casper.NewContractHash(strings.Split(contractHash, "-")[1])
@@ -312,17 +277,7 @@ func InitializeWasmFeature(ctx *godog.ScenarioContext) {
 					},
 				}
 
-				payment := types.StandardPayment(big.NewInt(2500000000))
-
-				deploy, err = types.MakeDeploy(header, payment, session)
-
-				if err == nil {
-					err = deploy.SignDeploy(faucetKey)
-				}
-
-				if err == nil {
-					wasmDeployResult, err = sdk.PutDeploy(context.Background(), *deploy)
-				}
+				wasmDeployResult, _, err = utils.PutDeployWithEstimatedPayment(header, session, faucetKey)
 			}
 
 			return err
@@ -333,7 +288,6 @@ func InitializeWasmFeature(ctx *godog.ScenarioContext) {
 			recipient, err := keypair.GeneratePrivateKey(keypair.ED25519)
 
 			if err == nil {
-				var deploy *types.Deploy
 				var version json.Number = "1"
 
 				header := types.DefaultHeader()
@@ -358,17 +312,7 @@ func InitializeWasmFeature(ctx *godog.ScenarioContext) {
 					},
 				}
 
-				payment := types.StandardPayment(big.NewInt(2500000000))
-
-				deploy, err = types.MakeDeploy(header, payment, session)
-
-				if err == nil {
-					err = deploy.SignDeploy(faucetKey)
-				}
-
-				if err == nil {
-					wasmDeployResult, err = sdk.PutDeploy(context.Background(), *deploy)
-				}
+				wasmDeployResult, _, err = utils.PutDeployWithEstimatedPayment(header, session, faucetKey)
 			}
 
 			return err
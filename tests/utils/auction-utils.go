@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/types"
+	"github.com/make-software/casper-go-sdk/types/key"
+	"github.com/make-software/casper-go-sdk/types/keypair"
+)
+
+// The global state key of the system contract registry, the registry is stored under an all zero hash
+const systemContractRegistryKey = "system-contract-registry-0000000000000000000000000000000000000000000000000000000000000000"
+
+// The name of the auction contract in the system contract registry
+const AuctionContractName = "auction"
+
+// GetSystemContractHash resolves the hash of a system contract from the system contract registry in global state
+func GetSystemContractHash(name string) (key.ContractHash, error) {
+	sdk := getHelperRPCClient()
+
+	stateRootHash, err := sdk.GetStateRootHashLatest(context.Background())
+	if err != nil {
+		return key.ContractHash{}, err
+	}
+
+	hash := stateRootHash.StateRootHash.String()
+
+	stateResult, err := sdk.QueryGlobalStateByStateHash(context.Background(), &hash, systemContractRegistryKey, nil)
+	if err != nil {
+		return key.ContractHash{}, err
+	}
+
+	if stateResult.StoredValue.CLValue == nil {
+		return key.ContractHash{}, errors.New("system contract registry is not a CLValue")
+	}
+
+	registry, err := stateResult.StoredValue.CLValue.Value()
+	if err != nil {
+		return key.ContractHash{}, err
+	}
+
+	if registry.Map == nil {
+		return key.ContractHash{}, errors.New("system contract registry is not a map")
+	}
+
+	contractHash, ok := registry.Map.Find(name)
+	if !ok || contractHash.ByteArray == nil {
+		return key.ContractHash{}, fmt.Errorf("system contract %s not found in the registry", name)
+	}
+
+	return key.NewContract(hex.EncodeToString(contractHash.ByteArray.Bytes()))
+}
+
+// PutAuctionDeploy submits a deploy calling an entry point of the auction system contract signed by the signing key
+func PutAuctionDeploy(entryPoint string, args *types.Args, signingKey keypair.PrivateKey) (rpc.PutDeployResult, error) {
+	auctionHash, err := GetSystemContractHash(AuctionContractName)
+	if err != nil {
+		return rpc.PutDeployResult{}, err
+	}
+
+	header := types.DefaultHeader()
+	header.ChainName = GetChainName()
+	header.Account = signingKey.PublicKey()
+	header.Timestamp = types.Timestamp(time.Now())
+
+	session := types.ExecutableDeployItem{
+		StoredContractByHash: &types.StoredContractByHash{
+			Hash:       auctionHash,
+			EntryPoint: entryPoint,
+			Args:       args,
+		},
+	}
+
+	result, _, err := PutDeployWithEstimatedPayment(header, session, signingKey)
+
+	return result, err
+}
+
+// FindAuctionDelegator finds the bid entry of a delegator delegating to a validator in the auction state
+func FindAuctionDelegator(auctionState types.AuctionState, validator keypair.PublicKey, delegator keypair.PublicKey) *types.AuctionDelegators {
+	bid := FindAuctionBid(auctionState, validator)
+	if bid == nil {
+		return nil
+	}
+
+	for i, bidDelegator := range bid.Bid.Delegators {
+		if bidDelegator.PublicKey.Equals(delegator) {
+			return &bid.Bid.Delegators[i]
+		}
+	}
+
+	return nil
+}
+
+// GetUnbondingPurses obtains the unbonding purses of an account from global state, the SDK's StoredValue does not
+// support the 'Unbonding' type so these are read from the node's JSON
+func GetUnbondingPurses(stateRootHash string, publicKey keypair.PublicKey) ([]types.UnbondingPurse, error) {
+	var unbonding struct {
+		Result struct {
+			StoredValue struct {
+				Unbonding []types.UnbondingPurse `json:"Unbonding"`
+			} `json:"stored_value"`
+		} `json:"result"`
+	}
+
+	unbondingJson, err := QueryGlobalState(stateRootHash, "unbond-"+publicKey.AccountHash().ToHex())
+
+	if err == nil {
+		err = json.Unmarshal([]byte(unbondingJson), &unbonding)
+	}
+
+	return unbonding.Result.StoredValue.Unbonding, err
+}
+
+// IsEraValidator checks if a public key is one of the validators of an era
+func IsEraValidator(eraValidators types.EraValidators, publicKey keypair.PublicKey) bool {
+	for _, validatorWeight := range eraValidators.ValidatorWeights {
+		if validatorWeight.Validator.Equals(publicKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindAuctionBid finds the bid of a validator in the auction state
+func FindAuctionBid(auctionState types.AuctionState, validator keypair.PublicKey) *types.ValidatorBid {
+	for i, bid := range auctionState.Bids {
+		if bid.PublicKey.Equals(validator) {
+			return &auctionState.Bids[i]
+		}
+	}
+	return nil
+}
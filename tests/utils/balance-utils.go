@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/make-software/casper-go-sdk/types/keypair"
+)
+
+// GetMainPurseBalanceAtBlock obtains the balance of an account's main purse in the global state of a block
+func GetMainPurseBalanceAtBlock(publicKey keypair.PublicKey, blockHash string) (*big.Int, error) {
+	sdk := getHelperRPCClient()
+
+	accountInfo, err := sdk.GetAccountInfoByBlochHash(context.Background(), blockHash, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	stateRootHash, err := sdk.GetStateRootHashByHash(context.Background(), blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := stateRootHash.StateRootHash.String()
+
+	balance, err := sdk.GetAccountBalance(context.Background(), &hash, accountInfo.Account.MainPurse.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return balance.BalanceValue.Value(), nil
+}
+
+// GetMainPurseBalanceChange obtains the change in the balance of an account's main purse made by a block, the
+// balance in the block's global state less the balance in its parent's
+func GetMainPurseBalanceChange(publicKey keypair.PublicKey, blockHash string) (*big.Int, error) {
+	block, err := getHelperRPCClient().GetBlockByHash(context.Background(), blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := GetMainPurseBalanceAtBlock(publicKey, block.Block.Header.ParentHash.String())
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := GetMainPurseBalanceAtBlock(publicKey, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).Sub(after, before), nil
+}
@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/make-software/casper-go-sdk/types"
+	"github.com/make-software/casper-go-sdk/types/key"
+)
+
+// ValidatorWeights maps a validator's hex public key to its weight in an era
+type ValidatorWeights map[string]*big.Int
+
+// TotalWeight sums the weights of all the validators
+func (w ValidatorWeights) TotalWeight() *big.Int {
+	total := big.NewInt(0)
+	for _, weight := range w {
+		total.Add(total, weight)
+	}
+	return total
+}
+
+// GetEraValidatorWeights obtains the validator weights of the era a block belongs to, these are read from the auction
+// info at the block and if the era is not present there from the switch block of the previous era
+func GetEraValidatorWeights(block types.Block) (ValidatorWeights, error) {
+	auctionInfo, err := getHelperRPCClient().GetAuctionInfoByHash(context.Background(), block.Hash.String())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, eraValidators := range auctionInfo.AuctionState.EraValidators {
+		if eraValidators.EraID == block.Header.EraID {
+			weights := make(ValidatorWeights)
+			for _, validatorWeight := range eraValidators.ValidatorWeights {
+				weights[validatorWeight.Validator.ToHex()] = validatorWeight.Weight.Value()
+			}
+			return weights, nil
+		}
+	}
+
+	if block.Header.EraID == 0 {
+		return nil, fmt.Errorf("validators for era 0 not found in the auction info")
+	}
+
+	switchBlock, err := GetSwitchBlock(block.Header.EraID - 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetNextEraValidatorWeights(switchBlock)
+}
+
+// GetNextEraValidatorWeights obtains the validator weights for the following era from a switch block
+func GetNextEraValidatorWeights(switchBlock types.Block) (ValidatorWeights, error) {
+	if switchBlock.Header.EraEnd == nil {
+		return nil, fmt.Errorf("block %s is not a switch block", switchBlock.Hash.String())
+	}
+
+	weights := make(ValidatorWeights)
+	for _, validatorWeight := range switchBlock.Header.EraEnd.NextEraValidatorWeights {
+		weights[validatorWeight.Validator.ToHex()] = validatorWeight.Weight.Value()
+	}
+	return weights, nil
+}
+
+// GetSwitchBlock walks back from the latest block to find the switch block of the requested era
+func GetSwitchBlock(eraID uint32) (types.Block, error) {
+	sdk := getHelperRPCClient()
+
+	result, err := sdk.GetBlockLatest(context.Background())
+	if err != nil {
+		return types.Block{}, err
+	}
+
+	block := result.Block
+	for block.Header.EraID >= eraID {
+		if block.Header.EraID == eraID && block.Header.EraEnd != nil {
+			return block, nil
+		}
+
+		if block.Header.Height == 0 {
+			break
+		}
+
+		result, err = sdk.GetBlockByHash(context.Background(), block.Header.ParentHash.String())
+		if err != nil {
+			return types.Block{}, err
+		}
+		block = result.Block
+	}
+
+	return types.Block{}, fmt.Errorf("switch block for era %d not found", eraID)
+}
+
+// GetFinalitySignatureBytes creates the bytes a validator signs to finalise a block, the block hash followed by the
+// little endian era id
+func GetFinalitySignatureBytes(blockHash key.Hash, eraID uint64) []byte {
+	return binary.LittleEndian.AppendUint64(blockHash.Bytes(), eraID)
+}
+
+// VerifyBlockProofs checks every proof signature of the block against the public key of an era validator and returns
+// the fraction of the total era weight that signed the block
+func VerifyBlockProofs(block types.Block, weights ValidatorWeights) (*big.Rat, error) {
+	message := GetFinalitySignatureBytes(block.Hash, uint64(block.Header.EraID))
+	signedWeight := big.NewInt(0)
+
+	for _, proof := range block.Proofs {
+		weight, ok := weights[proof.PublicKey.ToHex()]
+		if !ok {
+			return nil, fmt.Errorf("proof signer %s is not a validator in era %d", proof.PublicKey.ToHex(), block.Header.EraID)
+		}
+
+		if err := proof.PublicKey.VerifySignature(message, proof.Signature); err != nil {
+			return nil, fmt.Errorf("invalid proof signature from %s: %w", proof.PublicKey.ToHex(), err)
+		}
+
+		signedWeight.Add(signedWeight, weight)
+	}
+
+	totalWeight := weights.TotalWeight()
+	if totalWeight.Sign() == 0 {
+		return nil, fmt.Errorf("era %d has no validator weight", block.Header.EraID)
+	}
+
+	return new(big.Rat).SetFrac(signedWeight, totalWeight), nil
+}
@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/make-software/casper-go-sdk/types"
+)
+
+// ChainIntegrityReport summarises a walk of the chain, the violations are collected rather than failing at the first
+// so a single walk reports every broken block
+type ChainIntegrityReport struct {
+	FromHeight   uint64
+	ToHeight     uint64
+	Blocks       uint64
+	SwitchBlocks uint64
+	// The heights of the blocks the SDK could not decode
+	DecodeFailures []uint64
+	Violations     []string
+}
+
+// Valid is true when no block of the walk broke the chain integrity
+func (r ChainIntegrityReport) Valid() bool {
+	return len(r.Violations) == 0
+}
+
+// Err describes the violations of the walk or is nil when the chain is valid
+func (r ChainIntegrityReport) Err() error {
+	if r.Valid() {
+		return nil
+	}
+	return fmt.Errorf("%d chain integrity violations between heights %d and %d:\n%s",
+		len(r.Violations), r.FromHeight, r.ToHeight, strings.Join(r.Violations, "\n"))
+}
+
+// WalkChain requests every block from the height to the height inclusive via the SDK and verifies the parent hash
+// linkage, monotonic heights and timestamps, that the era only changes after a switch block and that each block's state
+// root hash is the one chain_get_state_root_hash returns for its height. A block the SDK can not decode is reported as a
+// violation and the walk continues from the following block, an error is returned if a request fails
+func WalkChain(fromHeight uint64, toHeight uint64) (ChainIntegrityReport, error) {
+	sdk := getHelperRPCClient()
+	report := ChainIntegrityReport{FromHeight: fromHeight, ToHeight: toHeight}
+
+	var previous *types.Block
+
+	for height := fromHeight; height <= toHeight; height++ {
+		result, err := sdk.GetBlockByHeight(context.Background(), height)
+
+		if InspectRpcError(err).Kind == RpcErrorMalformedResponse {
+			report.DecodeFailures = append(report.DecodeFailures, height)
+			report.Violations = append(report.Violations, fmt.Sprintf("block %d: could not be decoded: %v", height, err))
+			previous = nil
+			continue
+		}
+
+		if err != nil {
+			return report, fmt.Errorf("block at height %d: %w", height, err)
+		}
+
+		block := result.Block
+		report.Blocks++
+
+		violate := func(format string, args ...any) {
+			report.Violations = append(report.Violations, fmt.Sprintf("block %d %s: ", height, block.Hash.String())+fmt.Sprintf(format, args...))
+		}
+
+		if block.Header.Height != height {
+			violate("has the height %d", block.Header.Height)
+		}
+
+		if block.Header.EraEnd != nil {
+			report.SwitchBlocks++
+		}
+
+		if height == 0 && block.Header.EraID != 0 {
+			violate("the genesis block is in era %d", block.Header.EraID)
+		}
+
+		if previous != nil {
+			verifyChainLink(*previous, block, violate)
+		}
+
+		stateRootHash, err := sdk.GetStateRootHashByHeight(context.Background(), height)
+		if err != nil {
+			return report, fmt.Errorf("state root hash at height %d: %w", height, err)
+		}
+
+		if stateRootHash.StateRootHash.String() != block.Header.StateRootHash.String() {
+			violate("the state root hash %s is not chain_get_state_root_hash %s",
+				block.Header.StateRootHash.String(), stateRootHash.StateRootHash.String())
+		}
+
+		previous = &block
+	}
+
+	return report, nil
+}
+
+// WalkChainToTip walks the chain from the genesis block to the latest block
+func WalkChainToTip() (ChainIntegrityReport, error) {
+	latest, err := getHelperRPCClient().GetBlockLatest(context.Background())
+	if err != nil {
+		return ChainIntegrityReport{}, err
+	}
+
+	return WalkChain(0, latest.Block.Header.Height)
+}
+
+// verifyChainLink checks a block follows on from its parent
+func verifyChainLink(parent types.Block, block types.Block, violate func(format string, args ...any)) {
+	if block.Header.ParentHash.String() != parent.Hash.String() {
+		violate("the parent hash %s is not the hash of block %d %s", block.Header.ParentHash.String(), parent.Header.Height, parent.Hash.String())
+	}
+
+	if block.Header.Height != parent.Header.Height+1 {
+		violate("the height does not follow the parent height %d", parent.Header.Height)
+	}
+
+	if !time.Time(block.Header.Timestamp).After(time.Time(parent.Header.Timestamp)) {
+		violate("the timestamp %s is not after the parent timestamp %s",
+			time.Time(block.Header.Timestamp).UTC().Format(time.RFC3339Nano), time.Time(parent.Header.Timestamp).UTC().Format(time.RFC3339Nano))
+	}
+
+	expectedEraID := parent.Header.EraID
+	if parent.Header.EraEnd != nil {
+		expectedEraID++
+	}
+
+	if block.Header.EraID != expectedEraID {
+		violate("is in era %d but the parent in era %d is a switch block: %t", block.Header.EraID, parent.Header.EraID, parent.Header.EraEnd != nil)
+	}
+}
@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var finalityThresholdPattern = regexp.MustCompile(`finality_threshold_fraction\s*=\s*\[\s*(\d+)\s*,\s*(\d+)\s*,?\s*]`)
+
+// GetChainspecToml obtains the node's chainspec.toml via the info_get_chainspec RPC API
+func GetChainspecToml() (string, error) {
+	chainspec, err := getHelperRPCClient().GetChainspec(context.Background())
+	if err != nil {
+		return "", err
+	}
+
+	chainspecBytes, err := hex.DecodeString(chainspec.ChainspecBytes.ChainspecBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return string(chainspecBytes), nil
+}
+
+// GetFinalityThresholdFraction obtains the fraction of validator weight required to finalise a block from the chainspec
+func GetFinalityThresholdFraction() (*big.Rat, error) {
+	chainspec, err := GetChainspecToml()
+	if err != nil {
+		return nil, err
+	}
+
+	match := finalityThresholdPattern.FindStringSubmatch(chainspec)
+	if match == nil {
+		return nil, fmt.Errorf("finality_threshold_fraction not found in the chainspec")
+	}
+
+	threshold, ok := new(big.Rat).SetString(match[1] + "/" + match[2])
+	if !ok {
+		return nil, fmt.Errorf("invalid finality_threshold_fraction [%s, %s]", match[1], match[2])
+	}
+
+	return threshold, nil
+}
+
+// GetChainspecValue obtains the value of the first setting with the name in the chainspec, quotes are removed
+func GetChainspecValue(name string) (string, error) {
+	chainspec, err := GetChainspecToml()
+	if err != nil {
+		return "", err
+	}
+
+	match := regexp.MustCompile(`(?m)^\s*` + regexp.QuoteMeta(name) + `\s*=\s*(.+?)\s*$`).FindStringSubmatch(chainspec)
+	if match == nil {
+		return "", fmt.Errorf("%s not found in the chainspec", name)
+	}
+
+	return strings.Trim(match[1], `'"`), nil
+}
+
+// GetMaxDeploySize obtains the maximum serialised size of a deploy in bytes from the chainspec
+func GetMaxDeploySize() (uint64, error) {
+	maxDeploySize, err := GetChainspecValue("max_deploy_size")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.ReplaceAll(maxDeploySize, "_", ""), 10, 64)
+}
+
+// GetWasmlessTransferCost obtains the cost of a native transfer in motes from the chainspec, this is the payment a
+// transfer deploy requires
+func GetWasmlessTransferCost() (*big.Int, error) {
+	transferCost, err := GetChainspecValue("wasmless_transfer_cost")
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(transferCost)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty wasmless_transfer_cost")
+	}
+
+	cost, ok := new(big.Int).SetString(strings.ReplaceAll(fields[0], "_", ""), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid wasmless_transfer_cost %s", transferCost)
+	}
+
+	return cost, nil
+}
+
+// GetMaxTtl obtains the maximum time to live of a deploy from the chainspec
+func GetMaxTtl() (time.Duration, error) {
+	maxTtl, err := GetChainspecValue("max_ttl")
+	if err != nil {
+		return 0, err
+	}
+	return ParseHumanDuration(maxTtl)
+}
+
+var humanDurationPattern = regexp.MustCompile(`(\d+)\s*([a-zA-Z]+)`)
+
+// The humantime units the node uses for the chainspec durations
+var humanDurationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond, "nsec": time.Nanosecond,
+	"us": time.Microsecond, "usec": time.Microsecond,
+	"ms": time.Millisecond, "msec": time.Millisecond,
+	"s": time.Second, "sec": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+// ParseHumanDuration parses a humantime duration of the chainspec such as '18hours' or '1day 2h 30min', the sum of
+// every number and unit
+func ParseHumanDuration(value string) (time.Duration, error) {
+	matches := humanDurationPattern.FindAllStringSubmatch(value, -1)
+
+	if matches == nil || strings.TrimSpace(humanDurationPattern.ReplaceAllString(value, "")) != "" {
+		return 0, fmt.Errorf("invalid duration %s", value)
+	}
+
+	var duration time.Duration
+
+	for _, match := range matches {
+		amount, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+
+		unit, ok := humanDurationUnits[match[2]]
+		if !ok {
+			return 0, fmt.Errorf("invalid duration unit %s in %s", match[2], value)
+		}
+
+		duration += time.Duration(amount) * unit
+	}
+
+	return duration, nil
+}
@@ -21,7 +21,10 @@ func TestFeatures(t *testing.T, featureName string, scenarioInitializer func(*go
 	log.Printf("Working dir: %s ", dir)
 
 	suite := godog.TestSuite{
-		ScenarioInitializer: scenarioInitializer,
+		ScenarioInitializer: func(ctx *godog.ScenarioContext) {
+			scenarioInitializer(ctx)
+			registerRpcErrorSteps(ctx)
+		},
 		Options: &godog.Options{
 			Format:   "pretty",
 			Paths:    []string{"../features/" + featureName},
@@ -0,0 +1,377 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/make-software/casper-go-sdk/casper"
+	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/sse"
+	"github.com/make-software/casper-go-sdk/types"
+)
+
+// DeployState is a stage of the lifecycle of a deploy
+type DeployState int
+
+const (
+	DeployPut DeployState = iota
+	DeployAccepted
+	DeployIncluded
+	DeployProcessed
+	DeployVisible
+	DeployExpired
+)
+
+var deployStateNames = map[DeployState]string{
+	DeployPut:       "put",
+	DeployAccepted:  "accepted",
+	DeployIncluded:  "included",
+	DeployProcessed: "processed",
+	DeployVisible:   "visible",
+	DeployExpired:   "expired",
+}
+
+func (s DeployState) String() string {
+	return deployStateNames[s]
+}
+
+// ParseDeployState obtains the deploy state from its name
+func ParseDeployState(name string) (DeployState, error) {
+	for state, stateName := range deployStateNames {
+		if stateName == name {
+			return state, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown deploy state %s", name)
+}
+
+// TrackedDeploy is the recorded lifecycle of a deploy
+type TrackedDeploy struct {
+	Hash string
+	// The time each state was first reached
+	Times map[DeployState]time.Time
+	// The height of the latest block when the deploy was put
+	PutHeight   uint64
+	BlockHash   string
+	BlockHeight uint64
+}
+
+// Reached checks if the deploy has reached a state
+func (d TrackedDeploy) Reached(state DeployState) bool {
+	_, ok := d.Times[state]
+	return ok
+}
+
+// Latency obtains the time taken to get from one state to another
+func (d TrackedDeploy) Latency(from DeployState, to DeployState) (time.Duration, bool) {
+	fromTime, fromOk := d.Times[from]
+	toTime, toOk := d.Times[to]
+	return toTime.Sub(fromTime), fromOk && toOk
+}
+
+// DeployTracker records the lifecycle of the deploys put by the tests from the node's live event streams and RPC calls,
+// only the deploys put via the tracking RPC client are recorded
+type DeployTracker struct {
+	lock        sync.Mutex
+	deploys     map[string]*TrackedDeploy
+	latestBlock uint64
+	cancel      context.CancelFunc
+}
+
+var deployTracker = &DeployTracker{deploys: make(map[string]*TrackedDeploy)}
+
+// GetDeployTracker obtains the tracker shared by all the tests of a run
+func GetDeployTracker() *DeployTracker {
+	return deployTracker
+}
+
+// Put records a deploy being put to the node at the time the put was requested, it is called before the put so the
+// events the node sends while the put is in progress are recorded. False is returned if the deploy was already tracked
+func (t *DeployTracker) Put(deployHash string, putTime time.Time) bool {
+	putHeight, ok := t.getLatestBlockHeight()
+	if !ok {
+		if latest, err := getHelperRPCClient().GetBlockLatest(context.Background()); err == nil {
+			putHeight = latest.Block.Header.Height
+		}
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.deploys[deployHash]; ok {
+		return false
+	}
+
+	t.deploys[deployHash] = &TrackedDeploy{
+		Hash:      deployHash,
+		Times:     map[DeployState]time.Time{DeployPut: putTime},
+		PutHeight: putHeight,
+	}
+
+	return true
+}
+
+// Forget stops tracking a deploy, used when its put fails
+func (t *DeployTracker) Forget(deployHash string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.deploys, deployHash)
+}
+
+// Stop closes the tracker's event stream connections
+func (t *DeployTracker) Stop() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.cancel != nil {
+		t.cancel()
+	}
+}
+
+// Get obtains a copy of a tracked deploy
+func (t *DeployTracker) Get(deployHash string) (TrackedDeploy, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	deploy, ok := t.deploys[deployHash]
+	if !ok {
+		return TrackedDeploy{}, false
+	}
+
+	tracked := *deploy
+	tracked.Times = make(map[DeployState]time.Time)
+	for state, stateTime := range deploy.Times {
+		tracked.Times[state] = stateTime
+	}
+
+	return tracked, true
+}
+
+// WaitForState waits for a tracked deploy to reach a state
+func (t *DeployTracker) WaitForState(deployHash string, state DeployState, timeoutSeconds int) (TrackedDeploy, error) {
+	timeout := time.Now().Add(time.Duration(timeoutSeconds) * time.Second)
+
+	for {
+		deploy, ok := t.Get(deployHash)
+
+		if ok && deploy.Reached(state) {
+			return deploy, nil
+		}
+
+		if time.Now().After(timeout) {
+			return deploy, fmt.Errorf("timed-out waiting for deploy %s to be %s", deployHash, state)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// Summary describes the latencies between the states of the deploys put during the run
+func (t *DeployTracker) Summary() string {
+	transitions := [][2]DeployState{
+		{DeployPut, DeployAccepted},
+		{DeployAccepted, DeployIncluded},
+		{DeployIncluded, DeployProcessed},
+		{DeployProcessed, DeployVisible},
+		{DeployPut, DeployProcessed},
+		{DeployPut, DeployExpired},
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var summary strings.Builder
+	var putCount int
+
+	for _, deploy := range t.deploys {
+		if deploy.Reached(DeployPut) {
+			putCount++
+		}
+	}
+
+	if putCount == 0 {
+		return ""
+	}
+
+	summary.WriteString(fmt.Sprintf("Deploy lifecycle of %d deploys:\n", putCount))
+
+	for _, transition := range transitions {
+		var count int
+		var total, minimum, maximum time.Duration
+
+		for _, deploy := range t.deploys {
+			latency, ok := deploy.Latency(transition[0], transition[1])
+			if !ok || !deploy.Reached(DeployPut) {
+				continue
+			}
+
+			if count == 0 || latency < minimum {
+				minimum = latency
+			}
+			if latency > maximum {
+				maximum = latency
+			}
+			total += latency
+			count++
+		}
+
+		if count > 0 {
+			summary.WriteString(fmt.Sprintf("  %-9s -> %-9s count %d min %v avg %v max %v\n",
+				transition[0], transition[1], count, minimum.Round(time.Millisecond),
+				(total / time.Duration(count)).Round(time.Millisecond), maximum.Round(time.Millisecond)))
+		}
+	}
+
+	return summary.String()
+}
+
+// LogDeploySummary logs the deploy lifecycle summary of the run when any deploys were put
+func LogDeploySummary() {
+	if summary := GetDeployTracker().Summary(); summary != "" {
+		log.Print(summary)
+	}
+}
+
+// Start listens to the node's live event streams for the lifecycle events of deploys until the tracker is stopped,
+// waiting until both streams have sent their ApiVersion event so the events of the first deploy put are not missed. The
+// buffered events are not replayed as they precede the deploys put by the tests
+func (t *DeployTracker) Start(timeoutSeconds int) error {
+	t.lock.Lock()
+
+	if t.cancel != nil {
+		t.lock.Unlock()
+		return nil
+	}
+
+	var ctx context.Context
+	ctx, t.cancel = context.WithCancel(context.Background())
+
+	t.lock.Unlock()
+
+	connected := make(chan string, 2)
+
+	listen := func(stream string, eventTypes []sse.EventType) {
+		var connectedOnce sync.Once
+
+		_, _ = ListenWithResume(ctx, func() *sse.Client {
+			return GetSseStreamClient(stream)
+		}, eventTypes, -1, func(event sse.RawEvent) {
+			if event.EventType == sse.APIVersionEventType {
+				connectedOnce.Do(func() { connected <- stream })
+			}
+			t.onEvent(event)
+		})
+	}
+
+	go listen("main", MainStreamEventTypes)
+	go listen("deploys", []sse.EventType{sse.APIVersionEventType, sse.DeployAcceptedEventType})
+
+	timeout := time.After(time.Duration(timeoutSeconds) * time.Second)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-connected:
+		case <-timeout:
+			return fmt.Errorf("the deploy tracker event streams did not connect within %d seconds", timeoutSeconds)
+		}
+	}
+
+	return nil
+}
+
+func (t *DeployTracker) onEvent(event sse.RawEvent) {
+	switch event.EventType {
+	case sse.DeployAcceptedEventType:
+		if accepted, err := event.ParseAsDeployAcceptedEvent(); err == nil {
+			t.record(accepted.DeployAccepted.Hash.String(), DeployAccepted, time.Now(), nil)
+		}
+	case sse.BlockAddedEventType:
+		if blockAdded, err := event.ParseAsBlockAddedEvent(); err == nil {
+			t.onBlockAdded(blockAdded.BlockAdded.Block)
+		}
+	case sse.DeployProcessedEventType:
+		if processed, err := event.ParseAsDeployProcessedEvent(); err == nil {
+			t.record(processed.DeployProcessed.DeployHash.String(), DeployProcessed, time.Now(), nil)
+		}
+	case sse.DeployExpiredEventType:
+		if expired, err := event.ParseAsDeployExpiredEvent(); err == nil {
+			t.record(expired.DeployExpired.DeployHash.String(), DeployExpired, time.Now(), nil)
+		}
+	}
+}
+
+func (t *DeployTracker) onBlockAdded(block types.Block) {
+	t.lock.Lock()
+	if block.Header.Height > t.latestBlock {
+		t.latestBlock = block.Header.Height
+	}
+	t.lock.Unlock()
+
+	included := func(deploy *TrackedDeploy) {
+		deploy.BlockHash = block.Hash.String()
+		deploy.BlockHeight = block.Header.Height
+	}
+
+	for _, deployHash := range block.Body.DeployHashes {
+		t.record(deployHash.String(), DeployIncluded, time.Now(), included)
+	}
+
+	for _, transferHash := range block.Body.TransferHashes {
+		t.record(transferHash.String(), DeployIncluded, time.Now(), included)
+	}
+}
+
+func (t *DeployTracker) getLatestBlockHeight() (uint64, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.latestBlock, t.latestBlock > 0
+}
+
+// record sets the time a deploy put by the tests first reaches a state, other deploys are ignored
+func (t *DeployTracker) record(deployHash string, state DeployState, at time.Time, update func(deploy *TrackedDeploy)) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	deploy, ok := t.deploys[deployHash]
+	if !ok || deploy.Reached(state) {
+		return
+	}
+
+	deploy.Times[state] = at
+
+	if update != nil {
+		update(deploy)
+	}
+}
+
+// trackingRPCClient records the deploys put and their visibility via info_get_deploy with the deploy tracker
+type trackingRPCClient struct {
+	casper.RPCClient
+}
+
+func (c trackingRPCClient) PutDeploy(ctx context.Context, deploy types.Deploy) (rpc.PutDeployResult, error) {
+	// A rejected resubmission of a tracked deploy does not stop its tracking
+	added := GetDeployTracker().Put(deploy.Hash.String(), time.Now())
+
+	result, err := c.RPCClient.PutDeploy(ctx, deploy)
+
+	if err != nil && added {
+		GetDeployTracker().Forget(deploy.Hash.String())
+	}
+
+	return result, err
+}
+
+func (c trackingRPCClient) GetDeploy(ctx context.Context, hash string) (rpc.InfoGetDeployResult, error) {
+	result, err := c.RPCClient.GetDeploy(ctx, hash)
+
+	if err == nil && len(result.ExecutionResults) > 0 {
+		GetDeployTracker().record(hash, DeployVisible, time.Now(), nil)
+	}
+
+	return result, err
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/make-software/casper-go-sdk/types/key"
+	"golang.org/x/crypto/blake2b"
+)
+
+// GetErc20BalanceItemKey creates the ERC-20 'balances' dictionary item key for an account, this is the base64 encoding of
+// the account's Key bytes
+func GetErc20BalanceItemKey(accountHash key.AccountHash) string {
+	keyBytes := append([]byte{key.TypeIDAccount}, accountHash.Bytes()...)
+	return base64.StdEncoding.EncodeToString(keyBytes)
+}
+
+// ComputeDictionaryAddress computes the formatted dictionary Key of an item as the node does, the blake2b hash of the
+// seed URef address followed by the item key bytes
+func ComputeDictionaryAddress(seedUref key.URef, itemKey string) string {
+	uRefBytes := seedUref.Bytes()
+	address := blake2b.Sum256(append(uRefBytes[:key.ByteHashLen], []byte(itemKey)...))
+	return "dictionary-" + hex.EncodeToString(address[:])
+}
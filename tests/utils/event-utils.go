@@ -5,13 +5,14 @@ import (
 	"fmt"
 	"github.com/make-software/casper-go-sdk/casper"
 	"github.com/make-software/casper-go-sdk/sse"
+	"log"
 	"strings"
 	"time"
 )
 
 func WaitForDeploy(deployHash string, timeoutSeconds int) (casper.InfoGetDeployResult, error) {
 
-	sdk := GetRPCClient()
+	sdk := getHelperRPCClient()
 
 	var timeout = int64(timeoutSeconds*1000) + time.Now().UnixMilli()
 	var deploy = casper.InfoGetDeployResult{}
@@ -68,3 +69,83 @@ func WaitForBlockAdded(deployHash string, timeoutSeconds int) (sse.BlockAddedEve
 
 	return blockAddedEvent, err
 }
+
+// WaitForEvent listens to one of the node's event streams, replaying its buffered events, until an event of the
+// type is matched
+func WaitForEvent(stream string, eventType sse.EventType, timeoutSeconds int, match func(event sse.RawEvent) bool) (sse.RawEvent, error) {
+	var matched *sse.RawEvent
+	sseClient := GetSseStreamClient(stream)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+
+	sseClient.RegisterHandler(eventType, func(ctx context.Context, event sse.RawEvent) error {
+		if matched == nil && event.EventType == eventType && match(event) {
+			matched = &event
+			// Cancel so we stop listening
+			cancel()
+		}
+		return Pass
+	})
+
+	err := sseClient.Start(ctx, 0)
+
+	if matched != nil {
+		return *matched, nil
+	}
+
+	if err == nil || strings.Contains(err.Error(), "context") {
+		err = fmt.Errorf("timed-out waiting for a %s event on the %s stream", sse.AllEventsNames[eventType], stream)
+	}
+
+	return sse.RawEvent{}, err
+}
+
+// ListenWithResume listens to an event stream until the context is done. The SDK client does not reconnect so, each
+// time the stream ends, a new client is started from the event following the last one handled. The number of
+// reconnections is returned.
+func ListenWithResume(ctx context.Context, newClient func() *sse.Client, eventTypes []sse.EventType, startFrom int,
+	handler func(event sse.RawEvent)) (int, error) {
+
+	reconnects := 0
+	resumeFrom := startFrom
+
+	for {
+		sseClient := newClient()
+
+		for _, eventType := range eventTypes {
+			sseClient.RegisterHandler(eventType, func(_ context.Context, event sse.RawEvent) error {
+				// The ApiVersion event is sent on every connection without an id
+				if event.EventType != sse.APIVersionEventType {
+					resumeFrom = int(event.EventID) + 1
+				}
+				handler(event)
+				return Pass
+			})
+		}
+
+		err := sseClient.Start(ctx, resumeFrom)
+
+		if ctx.Err() != nil {
+			return reconnects, nil
+		}
+
+		if err == nil {
+			err = fmt.Errorf("the %s stream ended", sseClient.Streamer.Connection.URL)
+		}
+		log.Printf("Reconnecting from event %d: %v", resumeFrom, err)
+
+		reconnects++
+		sleepWithContext(ctx, pollInterval)
+	}
+}
+
+// MainStreamEventTypes are the types of event sent on the node's 'main' event stream
+var MainStreamEventTypes = []sse.EventType{
+	sse.APIVersionEventType,
+	sse.BlockAddedEventType,
+	sse.DeployProcessedEventType,
+	sse.DeployExpiredEventType,
+	sse.StepEventType,
+	sse.FaultEventType,
+}
@@ -0,0 +1,205 @@
+package utils
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/make-software/casper-go-sdk/types"
+	"github.com/make-software/casper-go-sdk/types/key"
+	"golang.org/x/crypto/blake2b"
+)
+
+// ComputeBlockHash recomputes a block's hash from the serialised bytes of its header
+func ComputeBlockHash(header types.BlockHeader) (key.Hash, error) {
+	headerBytes, err := GetBlockHeaderBytes(header)
+	if err != nil {
+		return key.Hash{}, err
+	}
+	return blake2b.Sum256(headerBytes), nil
+}
+
+// ComputeBlockBodyHash recomputes a block's body hash from the serialised bytes of its body
+func ComputeBlockBodyHash(body types.BlockBody) key.Hash {
+	return blake2b.Sum256(GetBlockBodyBytes(body))
+}
+
+// GetBlockHeaderBytes serialises a block header as the node does when hashing a block
+func GetBlockHeaderBytes(header types.BlockHeader) ([]byte, error) {
+	protocolVersion, err := getProtocolVersionBytes(header.ProtocolVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []byte
+	result = append(result, header.ParentHash.Bytes()...)
+	result = append(result, header.StateRootHash.Bytes()...)
+	result = append(result, header.BodyHash.Bytes()...)
+	result = append(result, boolToByte(header.RandomBit))
+
+	if header.AccumulatedSeed != nil {
+		result = append(result, header.AccumulatedSeed.Bytes()...)
+	} else {
+		result = append(result, make([]byte, key.ByteHashLen)...)
+	}
+
+	if header.EraEnd != nil {
+		result = append(result, 1)
+		result = append(result, getEraEndBytes(*header.EraEnd)...)
+	} else {
+		result = append(result, 0)
+	}
+
+	result = append(result, u64ToBytes(uint64(time.Time(header.Timestamp).UnixMilli()))...)
+	result = append(result, u64ToBytes(uint64(header.EraID))...)
+	result = append(result, u64ToBytes(header.Height)...)
+	result = append(result, protocolVersion...)
+
+	return result, nil
+}
+
+// GetBlockBodyBytes serialises a block body as the node does when hashing a block body
+func GetBlockBodyBytes(body types.BlockBody) []byte {
+	var result []byte
+
+	if proposer := body.Proposer.PublicKeyOptional(); proposer != nil {
+		result = append(result, proposer.Bytes()...)
+	} else {
+		// The system proposer
+		result = append(result, 0)
+	}
+
+	result = append(result, getHashListBytes(body.DeployHashes)...)
+	result = append(result, getHashListBytes(body.TransferHashes)...)
+
+	return result
+}
+
+// ComputeDeployHash recomputes a deploy's hash from the serialised bytes of its header
+func ComputeDeployHash(deploy types.Deploy) key.Hash {
+	return blake2b.Sum256(deploy.Header.Bytes())
+}
+
+// ComputeDeployBodyHash recomputes a deploy's body hash from the serialised bytes of its payment and session
+func ComputeDeployBodyHash(deploy types.Deploy) (key.Hash, error) {
+	paymentBytes, err := deploy.Payment.Bytes()
+	if err != nil {
+		return key.Hash{}, err
+	}
+
+	sessionBytes, err := deploy.Session.Bytes()
+	if err != nil {
+		return key.Hash{}, err
+	}
+
+	return blake2b.Sum256(append(paymentBytes, sessionBytes...)), nil
+}
+
+// VerifyDeployHashes recomputes a deploy's body hash and hash and checks they match the values the deploy reports
+func VerifyDeployHashes(deploy types.Deploy) error {
+	bodyHash, err := ComputeDeployBodyHash(deploy)
+	if err != nil {
+		return err
+	}
+
+	if bodyHash != deploy.Header.BodyHash {
+		return fmt.Errorf("deploy %s body hash %s does not match the computed %s",
+			deploy.Hash.String(), deploy.Header.BodyHash.String(), bodyHash.String())
+	}
+
+	if hash := ComputeDeployHash(deploy); hash != deploy.Hash {
+		return fmt.Errorf("deploy hash %s does not match the computed %s", deploy.Hash.String(), hash.String())
+	}
+
+	return nil
+}
+
+// VerifyBlockHashes recomputes a block's body hash and hash and checks they match the values the block reports
+func VerifyBlockHashes(block types.Block) error {
+	if bodyHash := ComputeBlockBodyHash(block.Body); bodyHash != block.Header.BodyHash {
+		return fmt.Errorf("block %s body hash %s does not match the computed %s",
+			block.Hash.String(), block.Header.BodyHash.String(), bodyHash.String())
+	}
+
+	hash, err := ComputeBlockHash(block.Header)
+	if err != nil {
+		return err
+	}
+
+	if hash != block.Hash {
+		return fmt.Errorf("block hash %s does not match the computed %s", block.Hash.String(), hash.String())
+	}
+
+	return nil
+}
+
+func getEraEndBytes(eraEnd types.EraEnd) []byte {
+	var result []byte
+
+	result = append(result, sizeToBytes(len(eraEnd.EraReport.Equivocators))...)
+	for _, equivocator := range eraEnd.EraReport.Equivocators {
+		result = append(result, equivocator.Bytes()...)
+	}
+
+	result = append(result, sizeToBytes(len(eraEnd.EraReport.Rewards))...)
+	for _, reward := range eraEnd.EraReport.Rewards {
+		result = append(result, reward.Validator.Bytes()...)
+		result = append(result, u64ToBytes(reward.Amount.Value().Uint64())...)
+	}
+
+	result = append(result, sizeToBytes(len(eraEnd.EraReport.InactiveValidators))...)
+	for _, inactive := range eraEnd.EraReport.InactiveValidators {
+		result = append(result, inactive.Bytes()...)
+	}
+
+	result = append(result, sizeToBytes(len(eraEnd.NextEraValidatorWeights))...)
+	for _, validatorWeight := range eraEnd.NextEraValidatorWeights {
+		result = append(result, validatorWeight.Validator.Bytes()...)
+		result = append(result, validatorWeight.Weight.Bytes()...)
+	}
+
+	return result
+}
+
+func getHashListBytes(hashes []key.Hash) []byte {
+	result := sizeToBytes(len(hashes))
+	for _, hash := range hashes {
+		result = append(result, hash.Bytes()...)
+	}
+	return result
+}
+
+func getProtocolVersionBytes(protocolVersion string) ([]byte, error) {
+	parts := strings.Split(protocolVersion, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid protocol version %s", protocolVersion)
+	}
+
+	var result []byte
+	for _, part := range parts {
+		value, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, u32ToBytes(uint32(value))...)
+	}
+
+	return result, nil
+}
+
+func boolToByte(value bool) byte {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+func u32ToBytes(value uint32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, value)
+}
+
+func u64ToBytes(value uint64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, value)
+}
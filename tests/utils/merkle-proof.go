@@ -0,0 +1,539 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/make-software/casper-go-sdk/types/clvalue"
+	"github.com/make-software/casper-go-sdk/types/clvalue/cltype"
+	"github.com/make-software/casper-go-sdk/types/key"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Decoding and verification of the hex encoded trie merkle proofs returned by the node's state RPC methods, the layout
+// follows the bytesrepr serialization of the execution engine's TrieMerkleProof
+
+const (
+	trieLeafTag      = 0
+	trieNodeTag      = 1
+	trieExtensionTag = 2
+
+	proofStepNodeTag      = 0
+	proofStepExtensionTag = 1
+
+	pointerLeafTag = 0
+	pointerNodeTag = 1
+)
+
+// TriePointer is a pointer to either a leaf or a node of the global state trie
+type TriePointer struct {
+	Tag  byte
+	Hash key.Hash
+}
+
+// IndexedPointer is a TriePointer held at an index in a trie node
+type IndexedPointer struct {
+	Index   byte
+	Pointer TriePointer
+}
+
+// TrieMerkleProofStep is a single step of a proof, either a node with a hole at the index of the proven path or an
+// extension
+type TrieMerkleProofStep struct {
+	HoleIndex       byte
+	IndexedPointers []IndexedPointer
+	Affix           []byte
+	IsExtension     bool
+}
+
+// TrieMerkleProof is the proof that a key and value are present in the global state trie
+type TrieMerkleProof struct {
+	// The bytes of the proven key
+	Key []byte
+	// The bytes of the proven stored value
+	Value []byte
+	Steps []TrieMerkleProofStep
+}
+
+// ParseMerkleProof decodes the JSON merkle_proof field of an RPC result
+func ParseMerkleProof(merkleProof json.RawMessage) ([]TrieMerkleProof, error) {
+	var hexProof string
+	if err := json.Unmarshal(merkleProof, &hexProof); err != nil {
+		return nil, err
+	}
+	return DecodeMerkleProof(hexProof)
+}
+
+// DecodeMerkleProof decodes a hex encoded list of trie merkle proofs, state_get_balance encodes a single proof rather
+// than a list so that is also accepted
+func DecodeMerkleProof(hexProof string) ([]TrieMerkleProof, error) {
+	proofBytes, err := hex.DecodeString(hexProof)
+	if err != nil {
+		return nil, err
+	}
+
+	proofs, err := decodeTrieMerkleProofs(bytes.NewBuffer(proofBytes))
+
+	if err != nil {
+		buf := bytes.NewBuffer(proofBytes)
+		proof, singleErr := decodeTrieMerkleProof(buf)
+
+		if singleErr == nil && buf.Len() == 0 {
+			return []TrieMerkleProof{proof}, nil
+		}
+	}
+
+	return proofs, err
+}
+
+func decodeTrieMerkleProofs(buf *bytes.Buffer) ([]TrieMerkleProof, error) {
+	count, err := readU32(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	proofs := make([]TrieMerkleProof, 0)
+
+	for i := uint32(0); i < count; i++ {
+		proof, err := decodeTrieMerkleProof(buf)
+		if err != nil {
+			return nil, fmt.Errorf("invalid merkle proof %d: %w", i, err)
+		}
+		proofs = append(proofs, proof)
+	}
+
+	if buf.Len() != 0 {
+		return nil, fmt.Errorf("%d unexpected trailing bytes in merkle proof", buf.Len())
+	}
+
+	return proofs, nil
+}
+
+// VerifyMerkleProof decodes a hex encoded merkle proof and checks every proof it contains computes the state root hash
+func VerifyMerkleProof(hexProof string, stateRootHash string) error {
+	proofs, err := DecodeMerkleProof(hexProof)
+	if err != nil {
+		return err
+	}
+
+	if len(proofs) == 0 {
+		return errors.New("merkle proof is empty")
+	}
+
+	for i, proof := range proofs {
+		computed := proof.ComputeStateRootHash()
+		if computed.String() != stateRootHash {
+			return fmt.Errorf("merkle proof %d computes state root hash %s expected %s", i, computed.String(), stateRootHash)
+		}
+	}
+
+	return nil
+}
+
+// ComputeStateRootHash hashes the proven leaf then each step of the proof to obtain the root of the trie
+func (p TrieMerkleProof) ComputeStateRootHash() key.Hash {
+	leaf := append([]byte{trieLeafTag}, p.Key...)
+	leaf = append(leaf, p.Value...)
+
+	var hash key.Hash = blake2b.Sum256(leaf)
+
+	for i, step := range p.Steps {
+		pointer := TriePointer{Tag: pointerNodeTag, Hash: hash}
+		if i == 0 {
+			pointer.Tag = pointerLeafTag
+		}
+
+		var trie []byte
+
+		if step.IsExtension {
+			trie = append([]byte{trieExtensionTag}, sizeToBytes(len(step.Affix))...)
+			trie = append(trie, step.Affix...)
+			trie = append(trie, pointer.bytes()...)
+		} else {
+			trie = append([]byte{trieNodeTag}, pointerBlockBytes(step, pointer)...)
+		}
+
+		hash = blake2b.Sum256(trie)
+	}
+
+	return hash
+}
+
+// ParseKey parses the proven key
+func (p TrieMerkleProof) ParseKey() (key.Key, error) {
+	return key.NewKeyFromBytes(p.Key)
+}
+
+// ParseCLValue parses the proven stored value when it is a CLValue
+func (p TrieMerkleProof) ParseCLValue() (clvalue.CLValue, error) {
+	if len(p.Value) == 0 || p.Value[0] != storedValueCLValueTag {
+		return clvalue.CLValue{}, errors.New("stored value is not a CLValue")
+	}
+	return clvalue.FromBytes(p.Value[1:])
+}
+
+func (p TriePointer) bytes() []byte {
+	return append([]byte{p.Tag}, p.Hash.Bytes()...)
+}
+
+// pointerBlockBytes serializes a node's pointer block with the hole filled by the pointer, every one of the 256 slots
+// is serialized as an optional pointer
+func pointerBlockBytes(step TrieMerkleProofStep, hole TriePointer) []byte {
+	var block [256]*TriePointer
+
+	for i := range step.IndexedPointers {
+		block[step.IndexedPointers[i].Index] = &step.IndexedPointers[i].Pointer
+	}
+	block[step.HoleIndex] = &hole
+
+	result := make([]byte, 0, len(block)*(key.ByteHashLen+2))
+
+	for _, pointer := range block {
+		if pointer == nil {
+			result = append(result, 0)
+		} else {
+			result = append(result, 1)
+			result = append(result, pointer.bytes()...)
+		}
+	}
+
+	return result
+}
+
+func decodeTrieMerkleProof(buf *bytes.Buffer) (TrieMerkleProof, error) {
+	var proof TrieMerkleProof
+
+	before := buf.Bytes()
+	if err := skipKey(buf); err != nil {
+		return proof, err
+	}
+	proof.Key = before[:len(before)-buf.Len()]
+
+	before = buf.Bytes()
+	if err := skipStoredValue(buf); err != nil {
+		return proof, err
+	}
+	proof.Value = before[:len(before)-buf.Len()]
+
+	count, err := readU32(buf)
+	if err != nil {
+		return proof, err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		var step TrieMerkleProofStep
+		step, err = decodeProofStep(buf)
+		if err != nil {
+			return proof, err
+		}
+		proof.Steps = append(proof.Steps, step)
+	}
+
+	return proof, nil
+}
+
+func decodeProofStep(buf *bytes.Buffer) (TrieMerkleProofStep, error) {
+	var step TrieMerkleProofStep
+
+	tag, err := buf.ReadByte()
+	if err != nil {
+		return step, err
+	}
+
+	switch tag {
+	case proofStepNodeTag:
+		step.HoleIndex, err = buf.ReadByte()
+		if err != nil {
+			return step, err
+		}
+
+		var count uint32
+		count, err = readU32(buf)
+
+		for i := uint32(0); err == nil && i < count; i++ {
+			var indexed IndexedPointer
+			indexed.Index, err = buf.ReadByte()
+			if err == nil {
+				indexed.Pointer, err = readPointer(buf)
+			}
+			step.IndexedPointers = append(step.IndexedPointers, indexed)
+		}
+	case proofStepExtensionTag:
+		step.IsExtension = true
+		step.Affix, err = readSizedBytes(buf)
+	default:
+		err = fmt.Errorf("invalid proof step tag %d", tag)
+	}
+
+	return step, err
+}
+
+func readPointer(buf *bytes.Buffer) (TriePointer, error) {
+	var pointer TriePointer
+	var err error
+
+	pointer.Tag, err = buf.ReadByte()
+	if err == nil && pointer.Tag != pointerLeafTag && pointer.Tag != pointerNodeTag {
+		err = fmt.Errorf("invalid pointer tag %d", pointer.Tag)
+	}
+
+	if err == nil {
+		pointer.Hash, err = key.NewByteHashFromBuffer(buf)
+	}
+
+	return pointer, err
+}
+
+const (
+	storedValueCLValueTag = iota
+	storedValueAccountTag
+	storedValueContractWasmTag
+	storedValueContractTag
+	storedValueContractPackageTag
+	storedValueTransferTag
+	storedValueDeployInfoTag
+	storedValueEraInfoTag
+	storedValueBidTag
+	storedValueWithdrawTag
+	storedValueUnbondingTag
+)
+
+// skipStoredValue moves the buffer past a serialized stored value
+func skipStoredValue(buf *bytes.Buffer) error {
+	tag, err := buf.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	switch tag {
+	case storedValueCLValueTag:
+		if _, err = readSizedBytes(buf); err == nil {
+			_, err = cltype.FromBuffer(buf)
+		}
+	case storedValueAccountTag:
+		err = skipAll(buf,
+			skipFixed(key.ByteHashLen),
+			skipNamedKeys,
+			skipFixed(key.ByteHashLen+1),
+			skipVec(skipFixed(key.ByteHashLen+1)),
+			skipFixed(2))
+	case storedValueContractWasmTag:
+		_, err = readSizedBytes(buf)
+	case storedValueContractTag:
+		err = skipAll(buf,
+			skipFixed(2*key.ByteHashLen),
+			skipNamedKeys,
+			skipVec(skipAllOf(skipString, skipEntryPoint)),
+			skipFixed(12))
+	case storedValueContractPackageTag:
+		err = skipAll(buf,
+			skipFixed(key.ByteHashLen+1),
+			skipVec(skipFixed(8+key.ByteHashLen)),
+			skipVec(skipFixed(8)),
+			skipVec(skipAllOf(skipString, skipVec(skipFixed(key.ByteHashLen+1)))),
+			skipFixed(1))
+	case storedValueTransferTag:
+		err = skipAll(buf,
+			skipFixed(2*key.ByteHashLen),
+			skipOption(skipFixed(key.ByteHashLen)),
+			skipFixed(2*(key.ByteHashLen+1)),
+			skipU512,
+			skipU512,
+			skipOption(skipFixed(8)))
+	case storedValueDeployInfoTag:
+		err = skipAll(buf,
+			skipFixed(key.ByteHashLen),
+			skipVec(skipFixed(key.ByteHashLen)),
+			skipFixed(key.ByteHashLen),
+			skipFixed(key.ByteHashLen+1),
+			skipU512)
+	case storedValueEraInfoTag:
+		err = skipVec(skipSeigniorageAllocation)(buf)
+	case storedValueBidTag:
+		err = skipAll(buf,
+			skipPublicKey,
+			skipFixed(key.ByteHashLen+1),
+			skipU512,
+			skipFixed(1),
+			skipOption(skipVestingSchedule),
+			skipVec(skipAllOf(skipPublicKey, skipPublicKey, skipU512, skipFixed(key.ByteHashLen+1), skipPublicKey, skipOption(skipVestingSchedule))),
+			skipFixed(1))
+	case storedValueWithdrawTag:
+		err = skipVec(skipAllOf(skipFixed(key.ByteHashLen+1), skipPublicKey, skipPublicKey, skipFixed(8), skipU512))(buf)
+	case storedValueUnbondingTag:
+		err = skipVec(skipAllOf(skipFixed(key.ByteHashLen+1), skipPublicKey, skipPublicKey, skipFixed(8), skipU512, skipOption(skipPublicKey)))(buf)
+	default:
+		err = fmt.Errorf("unsupported stored value tag %d", tag)
+	}
+
+	return err
+}
+
+type skipper func(buf *bytes.Buffer) error
+
+func skipAll(buf *bytes.Buffer, skippers ...skipper) error {
+	return skipAllOf(skippers...)(buf)
+}
+
+func skipAllOf(skippers ...skipper) skipper {
+	return func(buf *bytes.Buffer) error {
+		for _, skip := range skippers {
+			if err := skip(buf); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func skipFixed(length int) skipper {
+	return func(buf *bytes.Buffer) error {
+		if buf.Len() < length {
+			return io.ErrUnexpectedEOF
+		}
+		buf.Next(length)
+		return nil
+	}
+}
+
+func skipVec(item skipper) skipper {
+	return func(buf *bytes.Buffer) error {
+		count, err := readU32(buf)
+		for i := uint32(0); err == nil && i < count; i++ {
+			err = item(buf)
+		}
+		return err
+	}
+}
+
+func skipOption(item skipper) skipper {
+	return func(buf *bytes.Buffer) error {
+		tag, err := buf.ReadByte()
+		if err == nil && tag == 1 {
+			err = item(buf)
+		}
+		return err
+	}
+}
+
+func skipString(buf *bytes.Buffer) error {
+	_, err := readSizedBytes(buf)
+	return err
+}
+
+// skipKey moves the buffer past a serialized key, the SDK's key buffer parser does not consume era ids
+func skipKey(buf *bytes.Buffer) error {
+	tag, err := buf.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	switch tag {
+	case key.TypeIDEraId:
+		return skipFixed(8)(buf)
+	case key.TypeIDURef:
+		return skipFixed(key.ByteHashLen + 1)(buf)
+	default:
+		if tag > key.TypeIDChecksumRegistry {
+			return fmt.Errorf("invalid key tag %d", tag)
+		}
+		return skipFixed(key.ByteHashLen)(buf)
+	}
+}
+
+func skipCLType(buf *bytes.Buffer) error {
+	_, err := cltype.FromBuffer(buf)
+	return err
+}
+
+func skipU512(buf *bytes.Buffer) error {
+	length, err := buf.ReadByte()
+	if err == nil {
+		err = skipFixed(int(length))(buf)
+	}
+	return err
+}
+
+func skipPublicKey(buf *bytes.Buffer) error {
+	tag, err := buf.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	switch tag {
+	case 0:
+		return nil
+	case 1:
+		return skipFixed(32)(buf)
+	case 2:
+		return skipFixed(33)(buf)
+	default:
+		return fmt.Errorf("invalid public key tag %d", tag)
+	}
+}
+
+func skipNamedKeys(buf *bytes.Buffer) error {
+	return skipVec(skipAllOf(skipString, skipKey))(buf)
+}
+
+func skipEntryPoint(buf *bytes.Buffer) error {
+	return skipAll(buf,
+		skipString,
+		skipVec(skipAllOf(skipString, skipCLType)),
+		skipCLType,
+		func(buf *bytes.Buffer) error {
+			access, err := buf.ReadByte()
+			if err == nil && access == 1 {
+				err = skipVec(skipString)(buf)
+			}
+			return err
+		},
+		skipFixed(1))
+}
+
+func skipSeigniorageAllocation(buf *bytes.Buffer) error {
+	tag, err := buf.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	if tag == 0 {
+		return skipAll(buf, skipPublicKey, skipU512)
+	}
+	return skipAll(buf, skipPublicKey, skipPublicKey, skipU512)
+}
+
+func skipVestingSchedule(buf *bytes.Buffer) error {
+	lockedAmounts := make([]skipper, 14)
+	for i := range lockedAmounts {
+		lockedAmounts[i] = skipU512
+	}
+	return skipAll(buf, skipFixed(8), skipOption(skipAllOf(lockedAmounts...)))
+}
+
+func readU32(buf *bytes.Buffer) (uint32, error) {
+	if buf.Len() < 4 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return binary.LittleEndian.Uint32(buf.Next(4)), nil
+}
+
+func readSizedBytes(buf *bytes.Buffer) ([]byte, error) {
+	length, err := readU32(buf)
+	if err != nil {
+		return nil, err
+	}
+	if uint32(buf.Len()) < length {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return buf.Next(int(length)), nil
+}
+
+func sizeToBytes(size int) []byte {
+	return binary.LittleEndian.AppendUint32(nil, uint32(size))
+}
@@ -68,8 +68,7 @@ func GetAccountHash(publicKey string, blockHash string) (string, error) {
 func StateGetBalance(stateRootHash string, purseUref string) (big.Int, error) {
 	balance := new(big.Int)
 
-	params := fmt.Sprintf("{\"state_root_hash\":\"%s\",\"purse_uref\":\"%s\"}", stateRootHash, purseUref)
-	jsonStr, _ := simpleRcp("state_get_balance", params)
+	jsonStr, _ := GetStateBalanceJson(stateRootHash, purseUref)
 
 	balanceStr, err := GetByJsonPath(jsonStr, "/result/balance_value")
 
@@ -80,6 +79,11 @@ func StateGetBalance(stateRootHash string, purseUref string) (big.Int, error) {
 	return *balance, err
 }
 
+func GetStateBalanceJson(stateRootHash string, purseUref string) (string, error) {
+	params := fmt.Sprintf("{\"state_root_hash\":\"%s\",\"purse_uref\":\"%s\"}", stateRootHash, purseUref)
+	return simpleRcp("state_get_balance", params)
+}
+
 func GetByJsonPath(jsonStr string, path string) (string, error) {
 	node, err := GetNodeByJsonPath(jsonStr, path)
 	if err == nil {
@@ -126,6 +130,16 @@ func GetAuctionInfoByHash(hash string) (string, error) {
 	return auctionInfoJson, err
 }
 
+func GetAuctionInfoByHeight(height uint64) (string, error) {
+	auctionInfoJson, err := simpleRcp("state_get_auction_info", fmt.Sprintf("[{\"Height\": %d}]", height))
+	return auctionInfoJson, err
+}
+
+func QueryGlobalState(stateRootHash string, key string) (string, error) {
+	params := fmt.Sprintf("{\"state_identifier\":{\"StateRootHash\":\"%s\"},\"key\":\"%s\",\"path\":[]}", stateRootHash, key)
+	return simpleRcp("query_global_state", params)
+}
+
 func QueryBalance(purseIdentifierName string, identifier string) (string, error) {
 	params := fmt.Sprintf("{\"purse_identifier\":{\"%s\":\"%s\"}}", purseIdentifierName, identifier)
 	return simpleRcp("query_balance", params)
@@ -167,20 +181,58 @@ func simpleRcp(method string, params string) (string, error) {
 }
 
 func nodeExec(command string, params string) (string, error) {
+	strRes, err := nodeCommand(command, params)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return strRes, err
+}
+
+// nodeCommand runs a cctl command in the docker container returning any failure to the caller
+func nodeCommand(command string, params string) (string, error) {
 	docker := fmt.Sprintf("%v", config["docker-name"])
 	cmd := fmt.Sprintf("docker exec  -t %s /bin/bash -c -i '%s %s'", docker, command, params)
 
-	strRes := ""
-
 	res, err := exec.Command("/bin/sh", "-c", cmd).Output()
 
 	if err != nil {
 		log.Printf("Could not run command: %s", cmd)
-		log.Fatal(err)
-	} else {
-		// Strip out ANSI control characters from response
-		strRes = stripansi.Strip(string(res))
+		return "", err
 	}
 
-	return strRes, err
+	// Strip out ANSI control characters from response
+	return stripansi.Strip(string(res)), nil
+}
+
+// StopNode stops a node of the test network, a failure is returned rather than ending the tests so the node can be
+// restarted
+func StopNode(nodeId int) error {
+	_, err := nodeCommand("cctl-infra-node-stop", fmt.Sprintf("node=%d", nodeId))
+	return err
+}
+
+// StartNode starts a stopped node of the test network
+func StartNode(nodeId int) error {
+	_, err := nodeCommand("cctl-infra-node-start", fmt.Sprintf("node=%d", nodeId))
+	return err
+}
+
+func GetBlockTransfersByHash(blockHash string) (string, error) {
+	params := fmt.Sprintf("{\"block_identifier\":{\"Hash\":\"%s\"}}", blockHash)
+	return simpleRcp("chain_get_block_transfers", params)
+}
+
+// GetBlock obtains a block by its hash from the test node
+func GetBlock(blockHash string) (casper.Block, error) {
+	block := casper.Block{}
+
+	res, err := nodeExec("cctl-chain-view-block", fmt.Sprintf("block=%s", blockHash))
+
+	if err == nil {
+		err = json.Unmarshal([]byte(res), &block)
+	}
+
+	return block, err
 }
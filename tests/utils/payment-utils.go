@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/make-software/casper-go-sdk/rpc"
+	"github.com/make-software/casper-go-sdk/types"
+	"github.com/make-software/casper-go-sdk/types/keypair"
+)
+
+// The payment used when speculatively executing a deploy to obtain its cost, large enough for any test contract
+const speculativePayment = 500000000000
+
+// The safety margin percentage applied to a speculative cost when the config.yml does not provide one
+const defaultPaymentMargin = 20
+
+// SpeculativeCost obtains the cost of executing a deploy built from the header and session via the speculative_exec
+// RPC API
+func SpeculativeCost(header types.DeployHeader, session types.ExecutableDeployItem, signingKey keypair.PrivateKey) (*big.Int, error) {
+	var result rpc.SpeculativeExecResult
+
+	deploy, err := types.MakeDeploy(header, types.StandardPayment(big.NewInt(speculativePayment)), session)
+
+	if err == nil {
+		err = deploy.SignDeploy(signingKey)
+	}
+
+	if err == nil {
+		result, err = GetSpeculativeClient().SpeculativeExec(context.Background(), *deploy, nil)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if result.ExecutionResult.Failure != nil {
+		return nil, fmt.Errorf("speculative execution of deploy %s failed: %s",
+			deploy.Hash.String(),
+			result.ExecutionResult.Failure.ErrorMessage)
+	}
+
+	if result.ExecutionResult.Success == nil {
+		return nil, fmt.Errorf("speculative execution of deploy %s returned no result", deploy.Hash.String())
+	}
+
+	return new(big.Int).SetUint64(result.ExecutionResult.Success.Cost), nil
+}
+
+// ApplyPaymentMargin adds the margin percentage to the cost
+func ApplyPaymentMargin(cost *big.Int, marginPercent int64) *big.Int {
+	payment := new(big.Int).Mul(cost, big.NewInt(100+marginPercent))
+	return payment.Div(payment, big.NewInt(100))
+}
+
+// MakeDeployWithEstimatedPayment builds and signs a deploy whose standard payment is its speculative cost plus the
+// configured margin
+func MakeDeployWithEstimatedPayment(header types.DeployHeader, session types.ExecutableDeployItem, signingKey keypair.PrivateKey) (*types.Deploy, error) {
+	deploy, _, err := MakeDeployWithEstimatedCost(header, session, signingKey)
+	return deploy, err
+}
+
+// MakeDeployWithEstimatedCost builds and signs a deploy whose standard payment is its speculative cost plus the
+// configured margin, the speculative cost the payment was obtained from is also returned
+func MakeDeployWithEstimatedCost(header types.DeployHeader, session types.ExecutableDeployItem, signingKey keypair.PrivateKey) (*types.Deploy, *big.Int, error) {
+	cost, err := SpeculativeCost(header, session, signingKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deploy, err := types.MakeDeploy(header, types.StandardPayment(ApplyPaymentMargin(cost, GetPaymentMargin())), session)
+
+	if err == nil {
+		err = deploy.SignDeploy(signingKey)
+	}
+
+	if err == nil {
+		err = VerifyDeployHashes(*deploy)
+	}
+
+	return deploy, cost, err
+}
+
+// PutDeployWithEstimatedPayment builds a deploy using MakeDeployWithEstimatedPayment and submits it to the node
+func PutDeployWithEstimatedPayment(header types.DeployHeader, session types.ExecutableDeployItem, signingKey keypair.PrivateKey) (rpc.PutDeployResult, *types.Deploy, error) {
+	result, deploy, _, err := PutDeployWithEstimatedCost(header, session, signingKey)
+	return result, deploy, err
+}
+
+// PutDeployWithEstimatedCost builds a deploy using MakeDeployWithEstimatedCost and submits it to the node, the
+// speculative cost the payment was obtained from is also returned
+func PutDeployWithEstimatedCost(header types.DeployHeader, session types.ExecutableDeployItem, signingKey keypair.PrivateKey) (rpc.PutDeployResult, *types.Deploy, *big.Int, error) {
+	deploy, cost, err := MakeDeployWithEstimatedCost(header, session, signingKey)
+	if err != nil {
+		return rpc.PutDeployResult{}, nil, nil, err
+	}
+
+	result, err := getHelperRPCClient().PutDeploy(context.Background(), *deploy)
+
+	return result, deploy, cost, err
+}
+
+// GetPaymentMargin obtains the 'payment-margin' percentage from the config.yml
+func GetPaymentMargin() int64 {
+	margin, err := strconv.ParseInt(fmt.Sprintf("%v", config["payment-margin"]), 10, 64)
+	if err != nil {
+		return defaultPaymentMargin
+	}
+	return margin
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/make-software/casper-go-sdk/rpc"
+)
+
+// GetRestJson obtains the JSON of an endpoint of the node's REST server on the config.yml 'port-rest'
+func GetRestJson(path string) (string, error) {
+	client := http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	//goland:noinspection HttpUrlsUsage
+	response, err := client.Get(fmt.Sprintf("http://%v:%v/%s", config["host-name"], config["port-rest"], path))
+	if err != nil {
+		return "", err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("invalid response %d from /%s", response.StatusCode, path)
+	}
+
+	bodyBytes, err := io.ReadAll(response.Body)
+
+	return string(bodyBytes), err
+}
+
+// GetRestStatus obtains the node status from the REST /status endpoint, it has the same fields as info_get_status
+func GetRestStatus() (rpc.InfoGetStatusResult, error) {
+	status := rpc.InfoGetStatusResult{}
+	err := getRestResult("status", &status)
+	return status, err
+}
+
+// GetRestChainspec obtains the raw chainspec bytes from the REST /chainspec endpoint, these are the chainspec_bytes of
+// info_get_chainspec
+func GetRestChainspec() (rpc.InfoGetChainspecResult, error) {
+	chainspec := rpc.InfoGetChainspecResult{}
+	err := getRestResult("chainspec", &chainspec.ChainspecBytes)
+	return chainspec, err
+}
+
+// GetRestValidatorChanges obtains the validator changes from the REST /validator-changes endpoint
+func GetRestValidatorChanges() (rpc.InfoGetValidatorChangesResult, error) {
+	changes := rpc.InfoGetValidatorChangesResult{}
+	err := getRestResult("validator-changes", &changes)
+	return changes, err
+}
+
+func getRestResult(path string, result any) error {
+	restJson, err := GetRestJson(path)
+
+	if err == nil {
+		err = json.Unmarshal([]byte(restJson), result)
+	}
+
+	return err
+}
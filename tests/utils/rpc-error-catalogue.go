@@ -0,0 +1,227 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+
+	"github.com/cucumber/godog"
+	"github.com/make-software/casper-go-sdk/rpc"
+	yml "gopkg.in/yaml.v2"
+)
+
+// RpcErrorDefinition is an entry of the rpc-errors.yml catalogue of the node's JSON-RPC errors
+type RpcErrorDefinition struct {
+	Name    string `yaml:"name"`
+	Code    int    `yaml:"code"`
+	Message string `yaml:"message"`
+}
+
+// RpcCall is the outcome of a scenario's RPC call under test
+type RpcCall struct {
+	Method string
+	Result RpcErrorResult
+}
+
+// rpcCallRecorder keeps the last RPC call made by a scenario via the RPC client of GetRPCClient
+type rpcCallRecorder struct {
+	lock sync.Mutex
+	call *RpcCall
+}
+
+func (r *rpcCallRecorder) record(method string, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.call = &RpcCall{Method: method, Result: InspectRpcError(err)}
+}
+
+func (r *rpcCallRecorder) last() (RpcCall, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.call == nil {
+		return RpcCall{}, false
+	}
+	return *r.call, true
+}
+
+type rpcCallKey struct{}
+
+// The recorder of the running scenario, the scenarios of a feature are run one at a time
+var (
+	scenarioRpcCalls     = &rpcCallRecorder{}
+	scenarioRpcCallsLock sync.Mutex
+)
+
+func getScenarioRpcCalls() *rpcCallRecorder {
+	scenarioRpcCallsLock.Lock()
+	defer scenarioRpcCallsLock.Unlock()
+	return scenarioRpcCalls
+}
+
+func setScenarioRpcCalls(recorder *rpcCallRecorder) {
+	scenarioRpcCallsLock.Lock()
+	defer scenarioRpcCallsLock.Unlock()
+	scenarioRpcCalls = recorder
+}
+
+// recordingHandler records each call of the RPC client of GetRPCClient as the scenario's last RPC call
+type recordingHandler struct {
+	rpc.Handler
+}
+
+func (h recordingHandler) ProcessCall(ctx context.Context, request rpc.RpcRequest) (rpc.RpcResponse, error) {
+	response, err := h.Handler.ProcessCall(ctx, request)
+
+	callErr := err
+	if callErr == nil && response.Error != nil {
+		callErr = response.Error
+	}
+
+	getScenarioRpcCalls().record(string(request.Method), callErr)
+
+	return response, err
+}
+
+var (
+	rpcErrorCatalogue     map[string]RpcErrorDefinition
+	rpcErrorCatalogueOnce sync.Once
+	rpcErrorCatalogueErr  error
+)
+
+// GetRpcErrorDefinition obtains an error from the rpc-errors.yml catalogue by its name
+func GetRpcErrorDefinition(name string) (RpcErrorDefinition, error) {
+	catalogue, err := getRpcErrorCatalogue()
+	if err != nil {
+		return RpcErrorDefinition{}, err
+	}
+
+	definition, ok := catalogue[name]
+	if !ok {
+		return definition, fmt.Errorf("%s is not in the rpc error catalogue", name)
+	}
+
+	return definition, nil
+}
+
+// FindRpcErrorName obtains the catalogue name of an error code, the code is returned when it is not catalogued
+func FindRpcErrorName(code int) string {
+	catalogue, _ := getRpcErrorCatalogue()
+
+	for name, definition := range catalogue {
+		if definition.Code == code {
+			return name
+		}
+	}
+	return fmt.Sprintf("%d", code)
+}
+
+// ExpectNamed checks the result is the JSON-RPC error with the name in the catalogue and its message matches
+func (r RpcErrorResult) ExpectNamed(name string) error {
+	definition, err := GetRpcErrorDefinition(name)
+	if err != nil {
+		return err
+	}
+
+	if r.Kind != RpcErrorResponse {
+		return fmt.Errorf("expected %s but got a %s error: %v", name, r.Kind, r.Err)
+	}
+
+	if r.RpcError.Code != definition.Code {
+		return fmt.Errorf("expected %s (%d) but got %s (%d): %s",
+			name, definition.Code, FindRpcErrorName(r.RpcError.Code), r.RpcError.Code, r.RpcError.Message)
+	}
+
+	if !regexp.MustCompile(definition.Message).MatchString(r.RpcError.Message) {
+		return fmt.Errorf("%s message %s does not match %s", name, r.RpcError.Message, definition.Message)
+	}
+
+	return nil
+}
+
+// WithRpcCall records the error returned by a scenario's RPC call under test in the scenario context, for a step whose
+// call under test is not the last call it makes or whose error is only found when the result is decoded
+func WithRpcCall(ctx context.Context, method string, err error) context.Context {
+	if recorder, ok := ctx.Value(rpcCallKey{}).(*rpcCallRecorder); ok {
+		recorder.record(method, err)
+	}
+	return ctx
+}
+
+// GetRpcCall obtains the scenario's last RPC call from the scenario context
+func GetRpcCall(ctx context.Context) (RpcCall, error) {
+	recorder, ok := ctx.Value(rpcCallKey{}).(*rpcCallRecorder)
+	if !ok {
+		return RpcCall{}, fmt.Errorf("the scenario has no RPC call recorder")
+	}
+
+	call, ok := recorder.last()
+	if !ok {
+		return call, fmt.Errorf("no RPC call has been made by the scenario")
+	}
+	return call, nil
+}
+
+// registerRpcErrorSteps adds the steps asserting the outcome of the scenario's last RPC call to every feature, each
+// scenario records its calls from the start with its own recorder
+func registerRpcErrorSteps(ctx *godog.ScenarioContext) {
+	ctx.Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+		recorder := &rpcCallRecorder{}
+		setScenarioRpcCalls(recorder)
+		return context.WithValue(ctx, rpcCallKey{}, recorder), nil
+	})
+
+	ctx.Step(`^the call fails with (\w+)$`, func(ctx context.Context, name string) error {
+		call, err := GetRpcCall(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err = call.Result.ExpectNamed(name); err != nil {
+			return fmt.Errorf("%s: %w", call.Method, err)
+		}
+
+		return Pass
+	})
+
+	ctx.Step(`^the call succeeds$`, func(ctx context.Context) error {
+		call, err := GetRpcCall(ctx)
+		if err != nil {
+			return err
+		}
+
+		if call.Result.Kind != RpcErrorNone {
+			return fmt.Errorf("%s failed with a %s error: %v", call.Method, call.Result.Kind, call.Result.Err)
+		}
+
+		return Pass
+	})
+}
+
+func getRpcErrorCatalogue() (map[string]RpcErrorDefinition, error) {
+	rpcErrorCatalogueOnce.Do(func() {
+		rpcErrorCatalogue, rpcErrorCatalogueErr = readRpcErrorCatalogue()
+	})
+	return rpcErrorCatalogue, rpcErrorCatalogueErr
+}
+
+func readRpcErrorCatalogue() (map[string]RpcErrorDefinition, error) {
+	f, err := os.ReadFile(root + "/rpc-errors.yml")
+	if err != nil {
+		return nil, err
+	}
+
+	var definitions []RpcErrorDefinition
+	if err = yml.Unmarshal(f, &definitions); err != nil {
+		return nil, err
+	}
+
+	catalogue := make(map[string]RpcErrorDefinition)
+	for _, definition := range definitions {
+		catalogue[definition.Name] = definition
+	}
+
+	return catalogue, nil
+}
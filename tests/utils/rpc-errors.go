@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/make-software/casper-go-sdk/rpc"
+)
+
+// RpcErrorKind classifies the error returned from an RPC call
+type RpcErrorKind int
+
+const (
+	// RpcErrorNone is no error
+	RpcErrorNone RpcErrorKind = iota
+	// RpcErrorResponse is a JSON-RPC error returned by the node
+	RpcErrorResponse
+	// RpcErrorHttpStatus is a non 2xx HTTP response
+	RpcErrorHttpStatus
+	// RpcErrorTimeout is a request that timed out
+	RpcErrorTimeout
+	// RpcErrorMalformedResponse is a response or result that could not be unmarshalled
+	RpcErrorMalformedResponse
+	// RpcErrorTransport is any other failure to send the request or read its response
+	RpcErrorTransport
+)
+
+var rpcErrorKindNames = map[RpcErrorKind]string{
+	RpcErrorNone:              "none",
+	RpcErrorResponse:          "rpc error",
+	RpcErrorHttpStatus:        "http status",
+	RpcErrorTimeout:           "timeout",
+	RpcErrorMalformedResponse: "malformed response",
+	RpcErrorTransport:         "transport",
+}
+
+func (k RpcErrorKind) String() string {
+	return rpcErrorKindNames[k]
+}
+
+// RpcErrorResult is the classified error of an RPC call
+type RpcErrorResult struct {
+	Kind RpcErrorKind
+	// The node's JSON-RPC error when the kind is RpcErrorResponse
+	RpcError rpc.RpcError
+	// The HTTP status code when the kind is RpcErrorHttpStatus
+	StatusCode int
+	Err        error
+}
+
+// InspectRpcError classifies an error returned by the SDK's RPC client, the SDK wraps the node's errors so these
+// are found with errors.As rather than a type assertion
+func InspectRpcError(err error) RpcErrorResult {
+	result := RpcErrorResult{Kind: RpcErrorNone, Err: err}
+
+	if err == nil {
+		return result
+	}
+
+	var rpcError *rpc.RpcError
+	var httpError *rpc.HttpError
+	var netError net.Error
+
+	switch {
+	case errors.As(err, &rpcError):
+		result.Kind = RpcErrorResponse
+		result.RpcError = *rpcError
+	case errors.As(err, &httpError):
+		result.Kind = RpcErrorHttpStatus
+		result.StatusCode = httpError.StatusCode
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netError) && netError.Timeout(),
+		// The SDK does not wrap the HTTP client's error so its timeouts can only be found from the message
+		errors.Is(err, rpc.ErrProcessHttpRequest) && isTimeoutMessage(err.Error()):
+		result.Kind = RpcErrorTimeout
+	case errors.Is(err, rpc.ErrRpcResponseUnmarshal), errors.Is(err, rpc.ErrResultUnmarshal):
+		result.Kind = RpcErrorMalformedResponse
+	default:
+		result.Kind = RpcErrorTransport
+	}
+
+	return result
+}
+
+// ExpectCode checks the result is a JSON-RPC error with the code
+func (r RpcErrorResult) ExpectCode(code int) error {
+	if r.Kind != RpcErrorResponse {
+		return fmt.Errorf("expected an rpc error with code %d but got a %s error: %v", code, r.Kind, r.Err)
+	}
+	return ExpectEqual(CasperT, "error code", r.RpcError.Code, code)
+}
+
+// ExpectMessage checks the result is a JSON-RPC error with the message
+func (r RpcErrorResult) ExpectMessage(message string) error {
+	if r.Kind != RpcErrorResponse {
+		return fmt.Errorf("expected an rpc error with message %s but got a %s error: %v", message, r.Kind, r.Err)
+	}
+	return ExpectEqual(CasperT, "error message", r.RpcError.Message, message)
+}
+
+func isTimeoutMessage(message string) bool {
+	return strings.Contains(message, "Client.Timeout") || strings.Contains(message, "deadline exceeded")
+}
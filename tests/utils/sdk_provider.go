@@ -3,23 +3,45 @@ package utils
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/make-software/casper-go-sdk/casper"
 	"github.com/make-software/casper-go-sdk/rpc"
 	"github.com/make-software/casper-go-sdk/sse"
 )
 
+// GetRPCClient creates a client for the node's RPC API, the deploys it puts are recorded by the deploy tracker and
+// each call is recorded as the scenario's last RPC call for the "the call fails with <name>" and "the call succeeds" steps
 func GetRPCClient() casper.RPCClient {
+	return newRPCClient(true)
+}
+
+// getHelperRPCClient creates the client the utils use for their calls, these are not recorded as the scenario's calls
+func getHelperRPCClient() casper.RPCClient {
+	return newRPCClient(false)
+}
+
+func newRPCClient(recorded bool) casper.RPCClient {
 	//goland:noinspection HttpUrlsUsage
-	return casper.NewRPCClient(casper.NewRPCHandler(
+	var handler rpc.Handler = casper.NewRPCHandler(
 		fmt.Sprintf("http://%v:%v/rpc", config["host-name"], config["port-rcp"]),
-		http.DefaultClient),
-	)
+		http.DefaultClient)
+
+	if recorded {
+		handler = recordingHandler{handler}
+	}
+
+	return trackingRPCClient{casper.NewRPCClient(handler)}
 }
 
 func GetSseClient() *sse.Client {
+	return GetSseStreamClient("main")
+}
+
+// GetSseStreamClient creates a client for one of the node's event streams: 'main', 'deploys' or 'sigs'
+func GetSseStreamClient(stream string) *sse.Client {
 	//goland:noinspection HttpUrlsUsage
-	return sse.NewClient(fmt.Sprintf("http://%v:%v/events/main", config["host-name"], config["port-sse"]))
+	return sse.NewClient(fmt.Sprintf("http://%v:%v/events/%s", config["host-name"], config["port-sse"], stream))
 }
 
 func GetSpeculativeClient() *rpc.SpeculativeClient {
@@ -28,3 +50,24 @@ func GetSpeculativeClient() *rpc.SpeculativeClient {
 		fmt.Sprintf("http://%v:%v/rpc", config["host-name"], config["port-spd"]), http.DefaultClient),
 	)
 }
+
+// GetNodeSseStreamClient creates a client for one of the event streams of a node of the test network
+func GetNodeSseStreamClient(nodeId int, stream string) (*sse.Client, error) {
+	port, err := GetNodeSsePort(nodeId)
+	if err != nil {
+		return nil, err
+	}
+
+	//goland:noinspection HttpUrlsUsage
+	return sse.NewClient(fmt.Sprintf("http://%v:%v/events/%s", config["host-name"], port, stream)), nil
+}
+
+// GetNodeSsePort obtains the event stream port of a node, the config.yml 'port-sse' is node-1's port with each
+// following node's port incremented by one
+func GetNodeSsePort(nodeId int) (int, error) {
+	port, err := strconv.Atoi(fmt.Sprintf("%v", config["port-sse"]))
+	if err != nil {
+		return 0, err
+	}
+	return port + nodeId - 1, nil
+}
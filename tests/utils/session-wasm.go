@@ -0,0 +1,184 @@
+package utils
+
+import (
+	"encoding/binary"
+
+	"github.com/make-software/casper-go-sdk/types/clvalue"
+	"github.com/make-software/casper-go-sdk/types/key"
+)
+
+// The casper host functions imported by the dictionary session, in the order of their function indices
+var dictionarySessionImports = []struct {
+	name   string
+	params int
+	result bool
+}{
+	{"casper_new_dictionary", 1, true},
+	{"casper_read_host_buffer", 3, true},
+	{"casper_put_key", 4, false},
+	{"casper_dictionary_put", 6, true},
+	{"casper_revert", 1, false},
+}
+
+// The function indices of the imported host functions
+const (
+	wasmNewDictionary = iota
+	wasmReadHostBuffer
+	wasmPutKey
+	wasmDictionaryPut
+	wasmRevert
+)
+
+// The memory layout of the dictionary session, the URef Key is the Key tag byte followed by the URef the host writes
+const (
+	wasmSizePtr    = 0
+	wasmWrittenPtr = 4
+	wasmKeyPtr     = 32
+	wasmURefPtr    = wasmKeyPtr + 1
+	wasmURefSize   = key.ByteHashLen + 1
+	wasmDataPtr    = 128
+)
+
+// BuildDictionarySessionWasm assembles the wasm of a session that creates a dictionary, stores its seed URef in the
+// account's named keys under the name and puts the value under the item key, as the casper-contract
+// storage::new_dictionary and storage::dictionary_put do. The session reverts with the host error of a failed call
+func BuildDictionarySessionWasm(name string, itemKey string, value clvalue.CLValue) ([]byte, error) {
+	valueBytes, err := clvalue.ToBytesWithType(value)
+	if err != nil {
+		return nil, err
+	}
+
+	// The name is a serialised string, the item key is its raw bytes
+	nameBytes := append(binary.LittleEndian.AppendUint32(nil, uint32(len(name))), name...)
+	namePtr := wasmDataPtr
+	itemKeyPtr := namePtr + len(nameBytes)
+	valuePtr := itemKeyPtr + len(itemKey)
+
+	data := append(append(nameBytes, itemKey...), valueBytes...)
+
+	var body []byte
+	body = wasmCall(body, wasmNewDictionary, wasmSizePtr)
+	body = wasmRevertOnError(body)
+
+	body = wasmConst(body, wasmURefPtr)
+	body = wasmConst(body, wasmSizePtr)
+	// i32.load align 2 offset 0
+	body = append(body, 0x28, 0x02, 0x00)
+	body = wasmCall(body, wasmReadHostBuffer, wasmWrittenPtr)
+	body = wasmRevertOnError(body)
+
+	body = wasmCall(body, wasmPutKey, namePtr, len(nameBytes), wasmKeyPtr, wasmURefSize+1)
+
+	body = wasmCall(body, wasmDictionaryPut, wasmURefPtr, wasmURefSize, itemKeyPtr, len(itemKey), valuePtr, len(valueBytes))
+	body = wasmRevertOnError(body)
+
+	body = append(body, 0x0b)
+
+	var types []byte
+	for _, function := range dictionarySessionImports {
+		types = append(types, wasmFunctionType(function.params, function.result)...)
+	}
+	types = append(types, wasmFunctionType(0, false)...)
+
+	module := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	module = wasmSection(module, 1, len(dictionarySessionImports)+1, types)
+	module = wasmSection(module, 2, len(dictionarySessionImports), wasmImportEntries())
+	module = wasmSection(module, 3, 1, wasmUnsigned(nil, uint32(len(dictionarySessionImports))))
+	// A single memory of one page
+	module = wasmSection(module, 5, 1, []byte{0x00, 0x01})
+	// The session's call function and its memory as a compiled contract exports them
+	exports := append(wasmName("call"), 0x00)
+	exports = wasmUnsigned(exports, uint32(len(dictionarySessionImports)))
+	exports = append(append(exports, wasmName("memory")...), 0x02, 0x00)
+	module = wasmSection(module, 7, 2, exports)
+
+	// The code has a single i32 local holding the result of the last host call
+	code := append([]byte{0x01, 0x01, 0x7f}, body...)
+	module = wasmSection(module, 10, 1, append(wasmUnsigned(nil, uint32(len(code))), code...))
+
+	// The Key tag of the URef Key and the name, item key and value
+	var segments []byte
+	segments = wasmDataSegment(segments, wasmKeyPtr, []byte{key.TypeIDURef})
+	segments = wasmDataSegment(segments, wasmDataPtr, data)
+	module = wasmSection(module, 11, 2, segments)
+
+	return module, nil
+}
+
+func wasmImportEntries() []byte {
+	var entries []byte
+	for i, function := range dictionarySessionImports {
+		entries = append(entries, wasmName("env")...)
+		entries = append(entries, wasmName(function.name)...)
+		entries = append(entries, 0x00)
+		entries = wasmUnsigned(entries, uint32(i))
+	}
+	return entries
+}
+
+func wasmFunctionType(params int, result bool) []byte {
+	functionType := []byte{0x60}
+	functionType = wasmUnsigned(functionType, uint32(params))
+	for i := 0; i < params; i++ {
+		functionType = append(functionType, 0x7f)
+	}
+	if result {
+		return append(functionType, 0x01, 0x7f)
+	}
+	return append(functionType, 0x00)
+}
+
+func wasmSection(module []byte, id byte, count int, content []byte) []byte {
+	section := append(wasmUnsigned(nil, uint32(count)), content...)
+	module = append(module, id)
+	module = wasmUnsigned(module, uint32(len(section)))
+	return append(module, section...)
+}
+
+func wasmDataSegment(segments []byte, offset int, data []byte) []byte {
+	segments = append(segments, 0x00)
+	segments = wasmConst(segments, offset)
+	segments = append(segments, 0x0b)
+	segments = wasmUnsigned(segments, uint32(len(data)))
+	return append(segments, data...)
+}
+
+func wasmCall(body []byte, function int, args ...int) []byte {
+	for _, arg := range args {
+		body = wasmConst(body, arg)
+	}
+	body = append(body, 0x10)
+	return wasmUnsigned(body, uint32(function))
+}
+
+// wasmRevertOnError reverts with the result of the preceding host call when it is not zero
+func wasmRevertOnError(body []byte) []byte {
+	// local.tee 0, if, local.get 0, call casper_revert, end
+	body = append(body, 0x22, 0x00, 0x04, 0x40, 0x20, 0x00, 0x10)
+	body = wasmUnsigned(body, wasmRevert)
+	return append(body, 0x0b)
+}
+
+func wasmConst(body []byte, value int) []byte {
+	body = append(body, 0x41)
+	for {
+		b := byte(value & 0x7f)
+		value >>= 7
+		if (value == 0 && b&0x40 == 0) || (value == -1 && b&0x40 != 0) {
+			return append(body, b)
+		}
+		body = append(body, b|0x80)
+	}
+}
+
+func wasmUnsigned(bytes []byte, value uint32) []byte {
+	for value >= 0x80 {
+		bytes = append(bytes, byte(value)|0x80)
+		value >>= 7
+	}
+	return append(bytes, byte(value))
+}
+
+func wasmName(name string) []byte {
+	return append(wasmUnsigned(nil, uint32(len(name))), name...)
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/make-software/casper-go-sdk/sse"
+)
+
+// EventStreamProxy is a local TCP proxy in front of the node's event stream port, it allows the tests to drop the
+// connections of event stream clients mid-stream
+type EventStreamProxy struct {
+	listener    net.Listener
+	target      string
+	lock        sync.Mutex
+	connections []net.Conn
+}
+
+// NewEventStreamProxy starts a proxy on a free local port forwarding to the config.yml 'port-sse'
+func NewEventStreamProxy() (*EventStreamProxy, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := &EventStreamProxy{
+		listener: listener,
+		target:   fmt.Sprintf("%v:%v", config["host-name"], config["port-sse"]),
+	}
+
+	go proxy.accept()
+
+	return proxy, nil
+}
+
+// GetStreamClient creates a client for one of the node's event streams connected through the proxy
+func (p *EventStreamProxy) GetStreamClient(stream string) *sse.Client {
+	//goland:noinspection HttpUrlsUsage
+	return sse.NewClient(fmt.Sprintf("http://%s/events/%s", p.listener.Addr().String(), stream))
+}
+
+// DropConnections closes all the open connections through the proxy returning the number closed
+func (p *EventStreamProxy) DropConnections() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for _, connection := range p.connections {
+		_ = connection.Close()
+	}
+
+	// Each proxied connection is a client and node connection pair
+	dropped := len(p.connections) / 2
+	p.connections = nil
+
+	return dropped
+}
+
+// Close stops the proxy and drops any open connections
+func (p *EventStreamProxy) Close() error {
+	p.DropConnections()
+	return p.listener.Close()
+}
+
+func (p *EventStreamProxy) accept() {
+	for {
+		client, err := p.listener.Accept()
+		if err != nil {
+			// The listener has been closed
+			return
+		}
+
+		node, err := net.Dial("tcp", p.target)
+		if err != nil {
+			_ = client.Close()
+			continue
+		}
+
+		p.lock.Lock()
+		p.connections = append(p.connections, client, node)
+		p.lock.Unlock()
+
+		go p.pipe(client, node)
+		go p.pipe(node, client)
+	}
+}
+
+func (p *EventStreamProxy) pipe(from net.Conn, to net.Conn) {
+	_, _ = io.Copy(to, from)
+	_ = from.Close()
+	_ = to.Close()
+}
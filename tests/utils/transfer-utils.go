@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/make-software/casper-go-sdk/types/clvalue"
+	"github.com/make-software/casper-go-sdk/types/key"
+	"github.com/make-software/casper-go-sdk/types/keypair"
+)
+
+// GetTransferTarget creates the CLValue of a native transfer target for an account identified by its 'public key',
+// 'account hash' bytes, account 'key' or main 'purse uref'
+func GetTransferTarget(targetKind string, publicKey keypair.PublicKey) (clvalue.CLValue, error) {
+	switch targetKind {
+	case "public key":
+		return clvalue.NewCLPublicKey(publicKey), nil
+
+	case "account hash":
+		return clvalue.NewCLByteArray(publicKey.AccountHash().Bytes()), nil
+
+	case "key":
+		accountHash := publicKey.AccountHash()
+		return clvalue.NewCLKey(key.Key{Type: key.TypeIDAccount, Account: &accountHash}), nil
+
+	case "purse uref":
+		sdk := getHelperRPCClient()
+
+		latest, err := sdk.GetBlockLatest(context.Background())
+		if err != nil {
+			return clvalue.CLValue{}, err
+		}
+
+		accountInfo, err := sdk.GetAccountInfoByBlochHash(context.Background(), latest.Block.Hash.String(), publicKey)
+		if err != nil {
+			return clvalue.CLValue{}, err
+		}
+
+		return clvalue.NewCLUref(accountInfo.Account.MainPurse), nil
+
+	default:
+		return clvalue.CLValue{}, fmt.Errorf("unknown transfer target %s", targetKind)
+	}
+}
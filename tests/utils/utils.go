@@ -1,12 +1,14 @@
 package utils
 
 import (
+	cryptorand "crypto/rand"
 	"encoding/json"
 	"fmt"
 	"github.com/make-software/casper-go-sdk/casper"
 	"github.com/make-software/casper-go-sdk/rpc"
 	"github.com/make-software/casper-go-sdk/types"
 	"github.com/make-software/casper-go-sdk/types/clvalue"
+	"github.com/make-software/casper-go-sdk/types/key"
 	"github.com/make-software/casper-go-sdk/types/keypair"
 	"github.com/stretchr/testify/assert"
 	yml "gopkg.in/yaml.v2"
@@ -59,6 +61,10 @@ func GetUserKeyAssetPath(networkId int, userId int, keyFilename string) string {
 	return fmt.Sprintf("../../assets/net-%d/user-%d/%s", networkId, userId, keyFilename)
 }
 
+func GetNodeKeyAssetPath(networkId int, nodeId int, keyFilename string) string {
+	return fmt.Sprintf("../../assets/net-%d/nodes/node-%d/keys/%s", networkId, nodeId, keyFilename)
+}
+
 func ExpectEqual(t *testing.T, attribute string, actual any, expected any) error {
 
 	if !assert.Equal(t, expected, actual) {
@@ -72,10 +78,9 @@ func CreateExpectError(attribute string, actual any, expected any) error {
 	return fmt.Errorf("%s expected %s to be %s", attribute, actual, expected)
 }
 
-func GetRpcError(err interface{}) rpc.RpcError {
-	rpcError := err.(*rpc.RpcError)
-	fmt.Println(rpcError.Code)
-	return *rpcError
+// GetRpcError obtains the node's JSON-RPC error from an RPC call error, an empty error is returned when there is none
+func GetRpcError(err error) rpc.RpcError {
+	return InspectRpcError(err).RpcError
 }
 
 func BuildStandardTransferDeploy(namedArgs types.Args) (*types.Deploy, error) {
@@ -152,6 +157,67 @@ func BuildStandardTransferDeploy(namedArgs types.Args) (*types.Deploy, error) {
 	return deploy, err
 }
 
+// BuildTransferDeploy builds a native transfer deploy signed by the sender, the target may be a public key, account
+// hash or purse URef CLValue. A native transfer costs the chainspec's wasmless_transfer_cost, see GetWasmlessTransferCost
+func BuildTransferDeploy(senderKey keypair.PrivateKey, target clvalue.CLValue, amount *big.Int, transferId uint64, paymentAmount *big.Int) (*types.Deploy, error) {
+	header := types.DefaultHeader()
+	header.ChainName = GetChainName()
+	header.Account = senderKey.PublicKey()
+	header.Timestamp = types.Timestamp(time.Now())
+	payment := types.StandardPayment(paymentAmount)
+
+	args := &types.Args{}
+	args.AddArgument("amount", *clvalue.NewCLUInt512(amount))
+	args.AddArgument("target", target)
+	args.AddArgument("id", clvalue.NewCLOption(*clvalue.NewCLUInt64(transferId)))
+
+	session := types.ExecutableDeployItem{
+		Transfer: &types.TransferDeployItem{
+			Args: *args,
+		},
+	}
+
+	deploy, err := types.MakeDeploy(header, payment, session)
+
+	if err == nil {
+		err = deploy.SignDeploy(senderKey)
+	}
+
+	return deploy, err
+}
+
+// BuildExpiringTransferDeploy builds a transfer deploy that will expire without being executed. A deploy can not be
+// included in a block until its dependencies have been, so the deploy depends on a random unknown deploy hash.
+func BuildExpiringTransferDeploy(ttl time.Duration, timestamp time.Time) (*types.Deploy, error) {
+	dependency := key.Hash{}
+	if _, err := cryptorand.Read(dependency[:]); err != nil {
+		return nil, err
+	}
+
+	deploy, err := BuildStandardTransferDeploy(types.Args{})
+	if err != nil {
+		return deploy, err
+	}
+
+	senderKey, err := casper.NewED25519PrivateKeyFromPEMFile(GetUserKeyAssetPath(1, 1, "secret_key.pem"))
+	if err != nil {
+		return deploy, err
+	}
+
+	header := deploy.Header
+	header.TTL = types.Duration(ttl)
+	header.Timestamp = types.Timestamp(timestamp)
+	header.Dependencies = []key.Hash{dependency}
+
+	deploy, err = types.MakeDeploy(header, deploy.Payment, deploy.Session)
+
+	if err == nil {
+		err = deploy.SignDeploy(senderKey)
+	}
+
+	return deploy, err
+}
+
 func GetConfigChainName() string {
 	return config["chain-name"].(string)
 }
@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/make-software/casper-go-sdk/casper"
+	"github.com/make-software/casper-go-sdk/sse"
+	"github.com/make-software/casper-go-sdk/types/keypair"
+)
+
+// The interval between RPC polls when the event stream is unavailable
+const pollInterval = time.Second
+
+// WaitForBlockHeight waits until the chain has a block at or above the height
+func WaitForBlockHeight(height uint64, timeoutSeconds int) (casper.Block, error) {
+	return waitForBlock(fmt.Sprintf("block height %d", height), timeoutSeconds, func(block casper.Block) bool {
+		return block.Header.Height >= height
+	})
+}
+
+// WaitForEra waits until the chain has reached the era
+func WaitForEra(eraID uint32, timeoutSeconds int) (casper.Block, error) {
+	return waitForBlock(fmt.Sprintf("era %d", eraID), timeoutSeconds, func(block casper.Block) bool {
+		return block.Header.EraID >= eraID
+	})
+}
+
+// WaitForNextSwitchBlock waits for the switch block that ends the current era
+func WaitForNextSwitchBlock(timeoutSeconds int) (casper.Block, error) {
+	latest, err := getHelperRPCClient().GetBlockLatest(context.Background())
+	if err != nil {
+		return casper.Block{}, err
+	}
+
+	eraID := latest.Block.Header.EraID
+	if latest.Block.Header.EraEnd != nil {
+		// The latest block has already ended its era so wait for the end of the following one
+		eraID++
+	}
+
+	return waitForBlock(fmt.Sprintf("the switch block of era %d", eraID), timeoutSeconds, func(block casper.Block) bool {
+		return block.Header.EraID == eraID && block.Header.EraEnd != nil
+	})
+}
+
+// WaitForFinalitySignatures waits until the block has been signed by the number of distinct validators, returning the
+// number of signatures seen. The signatures are received from the 'sigs' event stream while the block's proofs are polled
+// via the RPC API so a stalled stream does not stop the wait
+func WaitForFinalitySignatures(blockHash string, count int, timeoutSeconds int) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+
+	var lock sync.Mutex
+	signers := make(map[string]bool)
+
+	addSigner := func(publicKey keypair.PublicKey) int {
+		lock.Lock()
+		defer lock.Unlock()
+		signers[publicKey.ToHex()] = true
+		return len(signers)
+	}
+
+	countSigners := func() int {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(signers)
+	}
+
+	signed := make(chan struct{})
+	var signedOnce sync.Once
+
+	sseClient := GetSseStreamClient("sigs")
+	sseClient.RegisterHandler(sse.FinalitySignatureType, func(_ context.Context, event sse.RawEvent) error {
+		signature, err := event.ParseAsFinalitySignatureEvent()
+
+		if err == nil && signature.FinalitySignature.BlockHash.String() == blockHash &&
+			addSigner(signature.FinalitySignature.PublicKey) >= count {
+			signedOnce.Do(func() { close(signed) })
+		}
+
+		return Pass
+	})
+
+	eventCtx, cancelEvents := context.WithCancel(ctx)
+	defer cancelEvents()
+
+	go func() {
+		_ = sseClient.Start(eventCtx, 0)
+	}()
+
+	var err error
+
+	for {
+		var block casper.ChainGetBlockResult
+		block, err = getHelperRPCClient().GetBlockByHash(ctx, blockHash)
+
+		if err == nil {
+			for _, proof := range block.Block.Proofs {
+				if addSigner(proof.PublicKey) >= count {
+					signedOnce.Do(func() { close(signed) })
+				}
+			}
+		}
+
+		select {
+		case <-signed:
+			return countSigners(), nil
+		case <-ctx.Done():
+			return countSigners(), fmt.Errorf("timed-out waiting for %d finality signatures on block %s: %w", count, blockHash, errors.Join(err, ctx.Err()))
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// waitForBlock waits for the first block that meets the condition, the latest block is polled via the RPC API while
+// blocks are received from the event stream so a stalled stream does not stop the wait
+func waitForBlock(description string, timeoutSeconds int, condition func(casper.Block) bool) (casper.Block, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+
+	found := make(chan casper.Block, 1)
+
+	eventCtx, cancelEvents := context.WithCancel(ctx)
+	defer cancelEvents()
+
+	go listenForBlock(eventCtx, condition, found)
+
+	var err error
+
+	for {
+		var latest casper.ChainGetBlockResult
+		latest, err = getHelperRPCClient().GetBlockLatest(ctx)
+
+		if err == nil && condition(latest.Block) {
+			return latest.Block, nil
+		}
+
+		select {
+		case block := <-found:
+			return block, nil
+		case <-ctx.Done():
+			return casper.Block{}, fmt.Errorf("timed-out waiting for %s: %w", description, errors.Join(err, ctx.Err()))
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// listenForBlock listens to BlockAdded events until the context is done and sends the first block meeting the condition
+func listenForBlock(ctx context.Context, condition func(casper.Block) bool, found chan<- casper.Block) {
+	var foundOnce sync.Once
+
+	sseClient := GetSseClient()
+	sseClient.RegisterHandler(sse.BlockAddedEventType, func(_ context.Context, event sse.RawEvent) error {
+		blockAdded, err := event.ParseAsBlockAddedEvent()
+
+		if err == nil && condition(blockAdded.BlockAdded.Block) {
+			foundOnce.Do(func() { found <- blockAdded.BlockAdded.Block })
+		}
+
+		return Pass
+	})
+
+	_ = sseClient.Start(ctx, 0)
+}
+
+func sleepWithContext(ctx context.Context, duration time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(duration):
+	}
+}